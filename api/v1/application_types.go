@@ -33,6 +33,106 @@ type ApplicationSpec struct {
 	// Template to be used for this application
 	Template  string            `json:"template"`
 	Variables map[string]string `json:"variables,omitempty"`
+
+	// Patches applied, in order, to the rendered objects before they are applied
+	// +optional
+	Patches []Patch `json:"patches,omitempty"`
+
+	// Which existing objects braid takes over when it renders an object that
+	// is not controlled by this Application
+	// +kubebuilder:default=Orphaned
+	// +optional
+	Adoption AdoptionPolicy `json:"adoption,omitempty"`
+
+	// Number of revisions of the rendered manifests kept to roll back to
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Revision whose manifests are applied instead of rendering the
+	// templates, until the spec changes again
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RollbackTo *int64 `json:"rollbackTo,omitempty"`
+
+	// Seconds the objects of a revision may take to become healthy. A
+	// revision that is still progressing after it, or whose objects are
+	// degraded, is rolled back to the last healthy revision. Revisions are
+	// not rolled back when it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// Stop rendering and applying the templates. Objects already applied are
+	// left as they are until the Application is resumed or deleted.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// DefaultRevisionHistoryLimit is the number of revisions kept when
+// revisionHistoryLimit is not set.
+const DefaultRevisionHistoryLimit = 10
+
+// AdoptionPolicy decides whether braid takes over a rendered object that
+// already exists without being controlled by the Application. Objects
+// controlled by another owner are never adopted.
+// +kubebuilder:validation:Enum=Never;Orphaned;Always
+type AdoptionPolicy string
+
+const (
+	// NeverAdoptionPolicy leaves existing objects unchanged.
+	NeverAdoptionPolicy AdoptionPolicy = "Never"
+	// OrphanedAdoptionPolicy adopts objects orphaned by an Application of
+	// the same name, as labelled by the Orphan deletion policy.
+	OrphanedAdoptionPolicy AdoptionPolicy = "Orphaned"
+	// AlwaysAdoptionPolicy adopts every object without a controller.
+	AlwaysAdoptionPolicy AdoptionPolicy = "Always"
+)
+
+// AdoptedByLabel names the Application that adopted an object.
+const AdoptedByLabel = "braid.james-parker.dev/adopted-by"
+
+// PatchType is the format of a Patch.
+// +kubebuilder:validation:Enum=StrategicMerge;JSON6902
+type PatchType string
+
+const (
+	// StrategicMergePatchType merges a partial object into the rendered object.
+	// Kinds built into Kubernetes use strategic merge semantics, other kinds
+	// use JSON merge patch (RFC 7386) semantics.
+	StrategicMergePatchType PatchType = "StrategicMerge"
+
+	// JSON6902PatchType applies a list of JSON patch (RFC 6902) operations.
+	JSON6902PatchType PatchType = "JSON6902"
+)
+
+// PatchTarget selects the rendered objects a Patch applies to. Fields left
+// empty match every object.
+type PatchTarget struct {
+	// +optional
+	Group string `json:"group,omitempty"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// Patch modifies rendered objects of an Application.
+type Patch struct {
+	// Objects to patch, every rendered object when empty
+	// +optional
+	Target PatchTarget `json:"target,omitempty,omitzero"`
+
+	// +kubebuilder:default=StrategicMerge
+	// +optional
+	Type PatchType `json:"type,omitempty"`
+
+	// Patch document, as YAML or JSON
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
 }
 
 // ApplicationStatus defines the observed state of Application.
@@ -56,10 +156,178 @@ type ApplicationStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Objects applied for this Application, used to prune objects that are
+	// no longer rendered
+	// +optional
+	Inventory []ManagedObject `json:"inventory,omitempty"`
+
+	// Fields of rendered objects also managed by another field manager, as
+	// found by the last reconcile
+	// +optional
+	Conflicts []FieldConflict `json:"conflicts,omitempty"`
+
+	// CreateOnly objects that differ from their rendered manifest and were
+	// left unchanged by the last reconcile
+	// +optional
+	SkippedUpdates []ManagedObject `json:"skippedUpdates,omitempty"`
+
+	// Objects with the Retain deletion policy that are no longer rendered, or
+	// belong to an Application being deleted, waiting to be annotated with
+	// braid.james-parker.dev/allow-deletion=true
+	// +optional
+	Retained []ManagedObject `json:"retained,omitempty"`
+
+	// Existing objects braid took over for this Application
+	// +optional
+	Adopted []ManagedObject `json:"adopted,omitempty"`
+
+	// Existing objects that are rendered for this Application but were left
+	// unchanged by the last reconcile, because the adoption policy does not
+	// allow taking them over
+	// +optional
+	NotAdopted []ManagedObject `json:"notAdopted,omitempty"`
+
+	// Hash of the manifest last applied for each object, used to skip
+	// applying objects that have not changed
+	// +optional
+	Manifests []AppliedManifest `json:"manifests,omitempty"`
+
+	// When every object was last applied whether or not it changed
+	// +optional
+	LastResyncTime *metav1.Time `json:"lastResyncTime,omitempty"`
+
+	// Revision of the rendered manifests last applied
+	// +optional
+	CurrentRevision int64 `json:"currentRevision,omitempty"`
+
+	// Revisions of the rendered manifests kept to roll back to, oldest first
+	// +optional
+	Revisions []ApplicationRevision `json:"revisions,omitempty"`
+
+	// Rollback last requested by spec.rollbackTo
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+
+	// Last revision whose objects were all healthy
+	// +optional
+	LastHealthyRevision int64 `json:"lastHealthyRevision,omitempty"`
+
+	// Rollback to the last healthy revision made because a revision was
+	// degraded
+	// +optional
+	AutomaticRollback *AutomaticRollback `json:"automaticRollback,omitempty"`
 }
 
+// ApplicationRevision is a revision of the rendered manifests of an
+// Application, stored in a ControllerRevision with the variables and
+// manifests it was rendered with.
+type ApplicationRevision struct {
+	Revision int64 `json:"revision"`
+	// Name of the ControllerRevision
+	Name string `json:"name"`
+	// Templates the manifests were rendered from
+	// +optional
+	Templates []TemplateRevision `json:"templates,omitempty"`
+}
+
+// TemplateRevision is the resourceVersion of a template an Application was
+// rendered from.
+type TemplateRevision struct {
+	Kind            string `json:"kind"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// RollbackStatus records a rollback requested by spec.rollbackTo. The
+// rollback is in effect while the generation of the Application is the one
+// it was requested at.
+type RollbackStatus struct {
+	Revision   int64 `json:"revision"`
+	Generation int64 `json:"generation"`
+}
+
+// AutomaticRollback records a rollback from a degraded revision to the last
+// healthy one. It lasts while the templates render the degraded revision.
+type AutomaticRollback struct {
+	// Revision rolled back to
+	Revision int64 `json:"revision"`
+	// Degraded revision rolled back from
+	From int64 `json:"from"`
+}
+
+// ApplicationLabel is set on the ControllerRevisions of an Application to
+// its name.
+const ApplicationLabel = "braid.james-parker.dev/application"
+
+// ManagedObject identifies an object rendered for an Application.
+type ManagedObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// FieldConflict is a field of a rendered object that another field manager
+// owns.
+type FieldConflict struct {
+	ManagedObject `json:",inline"`
+	Field         string `json:"field"`
+	Manager       string `json:"manager"`
+	// Policy applied to the conflict
+	Policy ConflictPolicy `json:"policy"`
+}
+
+// AppliedManifest records the manifest braid last applied for an object.
+type AppliedManifest struct {
+	ManagedObject `json:",inline"`
+	// SHA-256 of the rendered manifest, also stored on the object in the
+	// braid.james-parker.dev/manifest-hash annotation
+	Hash string `json:"hash"`
+	// metadata.generation of the object once the manifest was applied
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// metadata.resourceVersion of the object once the manifest was applied,
+	// compared instead of the generation for kinds that do not have one
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// Condition types of an Application.
+const (
+	// ReadyCondition is True once every rendered object has been written.
+	ReadyCondition = "Ready"
+
+	// StalledCondition is True while the Application fails in a way that
+	// only a change to it or its templates can fix.
+	StalledCondition = "Stalled"
+
+	// ProgressingCondition is True while the applied objects are working
+	// towards the state of their manifests.
+	ProgressingCondition = "Progressing"
+
+	// DegradedCondition is True when an applied object failed to reach the
+	// state of its manifest, or did not reach it within the progress
+	// deadline.
+	DegradedCondition = "Degraded"
+
+	// RolledBackCondition is True while a degraded revision is rolled back
+	// to the last healthy revision.
+	RolledBackCondition = "RolledBack"
+
+	// SuspendedCondition is True while spec.suspend stops the Application
+	// from being reconciled.
+	SuspendedCondition = "Suspended"
+)
+
+// ManifestHashAnnotation records the hash of the manifest braid last applied
+// to an object.
+const ManifestHashAnnotation = "braid.james-parker.dev/manifest-hash"
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 
 // Application is the Schema for the applications API
 type Application struct {
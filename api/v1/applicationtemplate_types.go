@@ -38,8 +38,93 @@ type ApplicationTemplateSpec struct {
 type ApplicationObject struct {
 	Template  string            `json:"template,omitempty"`
 	Variables map[string]string `json:"variables,omitempty"`
+
+	// How server-side apply conflicts with other field managers are handled
+	// for the objects rendered from this template
+	// +kubebuilder:default=Fail
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// How the objects rendered from this template are created and updated
+	// +kubebuilder:default=ServerSideApply
+	// +optional
+	ApplyStrategy ApplyStrategy `json:"applyStrategy,omitempty"`
+
+	// Fields of the objects rendered from this template braid leaves alone
+	// once they exist, in addition to those ignored by the ObjectTemplate
+	// +optional
+	IgnoreDifferences []IgnoreDifference `json:"ignoreDifferences,omitempty"`
+
+	// What happens to the objects rendered from this template when they are
+	// pruned or the Application is deleted
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DeletionPolicy decides what happens to a rendered object when braid no
+// longer manages it. It is recorded on the object in the
+// braid.james-parker.dev/deletion-policy annotation, so it is honoured after
+// the object has been removed from the template.
+// +kubebuilder:validation:Enum=Delete;Orphan;Retain
+type DeletionPolicy string
+
+const (
+	// DeleteDeletionPolicy deletes the object.
+	DeleteDeletionPolicy DeletionPolicy = "Delete"
+	// OrphanDeletionPolicy leaves the object in the cluster without the
+	// owner reference to the Application, labelled with the Application it
+	// came from so it can be adopted later.
+	OrphanDeletionPolicy DeletionPolicy = "Orphan"
+	// RetainDeletionPolicy keeps the object, and the Application while it is
+	// being deleted, until the object is annotated with
+	// braid.james-parker.dev/allow-deletion=true.
+	RetainDeletionPolicy DeletionPolicy = "Retain"
+)
+
+const (
+	// DeletionPolicyAnnotation records the DeletionPolicy of a rendered
+	// object.
+	DeletionPolicyAnnotation = "braid.james-parker.dev/deletion-policy"
+	// AllowDeletionAnnotation set to "true" lets braid delete an object with
+	// the Retain policy.
+	AllowDeletionAnnotation = "braid.james-parker.dev/allow-deletion"
+	// OrphanedFromLabel names the Application an orphaned object belonged to.
+	OrphanedFromLabel = "braid.james-parker.dev/orphaned-from"
+)
+
+// ApplyStrategy decides how braid writes a rendered object.
+// +kubebuilder:validation:Enum=CreateOnly;ServerSideApply;Replace;MergePatch
+type ApplyStrategy string
+
+const (
+	// CreateOnlyStrategy creates the object if it is missing and never
+	// updates it.
+	CreateOnlyStrategy ApplyStrategy = "CreateOnly"
+	// ServerSideApplyStrategy applies the object with server-side apply.
+	ServerSideApplyStrategy ApplyStrategy = "ServerSideApply"
+	// ReplaceStrategy replaces the whole object with an update, dropping
+	// fields set by anyone else.
+	ReplaceStrategy ApplyStrategy = "Replace"
+	// MergePatchStrategy updates the object with a JSON merge patch.
+	MergePatchStrategy ApplyStrategy = "MergePatch"
+)
+
+// ConflictPolicy decides what happens when another field manager owns a field
+// braid applies.
+// +kubebuilder:validation:Enum=Fail;Force;SkipField
+type ConflictPolicy string
+
+const (
+	// FailConflictPolicy leaves the object unchanged and fails the reconcile.
+	FailConflictPolicy ConflictPolicy = "Fail"
+	// ForceConflictPolicy takes ownership of the conflicting fields.
+	ForceConflictPolicy ConflictPolicy = "Force"
+	// SkipFieldConflictPolicy applies the object without the conflicting
+	// fields, leaving them to their current manager.
+	SkipFieldConflictPolicy ConflictPolicy = "SkipField"
+)
+
 // ApplicationTemplateStatus defines the observed state of ApplicationTemplate.
 type ApplicationTemplateStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -61,10 +146,70 @@ type ApplicationTemplateStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Number of Applications using the template
+	// +optional
+	Applications int32 `json:"applications,omitempty"`
+
+	// Number of Applications using the template whose Ready condition is
+	// True
+	// +optional
+	ReadyApplications int32 `json:"readyApplications,omitempty"`
+
+	// Number of Applications using the template whose Degraded condition is
+	// True
+	// +optional
+	DegradedApplications int32 `json:"degradedApplications,omitempty"`
+
+	// Number of Applications using the template that are suspended
+	// +optional
+	SuspendedApplications int32 `json:"suspendedApplications,omitempty"`
+
+	// Applications using the template and the revision of it they run
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Consumers []TemplateConsumer `json:"consumers,omitempty"`
+
+	// ObjectTemplates listed in objects and whether they exist
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ObjectTemplates []ObjectTemplateReference `json:"objectTemplates,omitempty"`
+}
+
+// TemplateConsumer is an Application using an ApplicationTemplate.
+type TemplateConsumer struct {
+	// Name of the Application
+	Name string `json:"name"`
+
+	// Revision of the Application currently applied
+	// +optional
+	Revision int64 `json:"revision,omitempty"`
+
+	// Resource version of the ApplicationTemplate the current revision of the
+	// Application was rendered from
+	// +optional
+	TemplateResourceVersion string `json:"templateResourceVersion,omitempty"`
+}
+
+// ObjectTemplateReference is an ObjectTemplate listed by an
+// ApplicationTemplate.
+type ObjectTemplateReference struct {
+	// Name of the ObjectTemplate
+	Name string `json:"name"`
+
+	// Whether the ObjectTemplate exists
+	Found bool `json:"found"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Applications",type=integer,JSONPath=`.status.applications`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyApplications`
+// +kubebuilder:printcolumn:name="Degraded",type=integer,JSONPath=`.status.degradedApplications`
+// +kubebuilder:printcolumn:name="Suspended",type=integer,JSONPath=`.status.suspendedApplications`
 
 // ApplicationTemplate is the Schema for the applicationtemplates API
 type ApplicationTemplate struct {
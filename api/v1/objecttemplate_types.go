@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,22 +25,119 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // ObjectTemplateSpec defines the desired state of ObjectTemplate
+// +kubebuilder:validation:XValidation:rule="has(self.manifests) || (has(self.apiVersion) && has(self.kind))",message="apiVersion and kind are required unless manifests is set"
 type ObjectTemplateSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// The following markers will use OpenAPI v3 schema to validate the value
 	// More info: https://book.kubebuilder.io/reference/markers/crd-validation.html
 
-	ApiVersion string `json:"apiVersion"`
-	Kind       string `json:"kind"`
+	// +optional
+	ApiVersion string `json:"apiVersion,omitempty"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
 
 	// foo is an example field of ObjectTemplate. Edit objecttemplate_types.go to remove/update
 	// +optional
 	Spec string `json:"spec,omitempty"`
 
+	// Manifests is a Go template producing a multi-document YAML stream of
+	// complete objects, rendered instead of apiVersion, kind and spec. Objects
+	// without a name are named after the Application.
+	// +optional
+	Manifests string `json:"manifests,omitempty"`
+
+	// Engine used to render spec or manifests
+	// +kubebuilder:default=GoTemplate
+	// +optional
+	Engine TemplateEngine `json:"engine,omitempty"`
+
 	Variables []string `json:"variables,omitempty"`
+
+	// Type, default and required flag of variables listed in variables.
+	// Variables without a definition are optional strings.
+	// +optional
+	VariableDefinitions []VariableDefinition `json:"variableDefinitions,omitempty"`
+
+	// Fields of the rendered objects braid leaves alone once they exist
+	// +optional
+	IgnoreDifferences []IgnoreDifference `json:"ignoreDifferences,omitempty"`
+
+	// Delete and create the rendered objects again when an update is
+	// rejected because it changes an immutable field
+	// +optional
+	RecreateOnImmutableChange *RecreateOnImmutableChange `json:"recreateOnImmutableChange,omitempty"`
+}
+
+// RecreateOnImmutableChange configures how objects are re-created when an
+// update changes an immutable field.
+type RecreateOnImmutableChange struct {
+	// Delete the object in the foreground, so its dependents, such as the
+	// Pods of a Job, are gone before it is created again
+	// +optional
+	WaitForDeletion bool `json:"waitForDeletion,omitempty"`
 }
 
+// IgnoreDifference selects fields braid stops applying once an object exists,
+// leaving them to whoever changes them in the cluster.
+type IgnoreDifference struct {
+	// Objects the fields are ignored on, every rendered object when empty
+	// +optional
+	Target PatchTarget `json:"target,omitempty,omitzero"`
+
+	// JSON pointers (RFC 6901) to ignored fields, such as /spec/replicas
+	// +optional
+	JSONPointers []string `json:"jsonPointers,omitempty"`
+
+	// Field managers whose fields are ignored, such as the manager of a
+	// HorizontalPodAutoscaler
+	// +optional
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
+}
+
+// VariableType is the JSON type a variable value must have.
+// +kubebuilder:validation:Enum=string;integer;number;boolean;object;array
+type VariableType string
+
+const (
+	VariableTypeString  VariableType = "string"
+	VariableTypeInteger VariableType = "integer"
+	VariableTypeNumber  VariableType = "number"
+	VariableTypeBoolean VariableType = "boolean"
+	VariableTypeObject  VariableType = "object"
+	VariableTypeArray   VariableType = "array"
+)
+
+// VariableDefinition declares the type and default of a variable.
+type VariableDefinition struct {
+	Name string `json:"name"`
+	// +kubebuilder:default=string
+	// +optional
+	Type VariableType `json:"type,omitempty"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Required variables must be set by the ApplicationTemplate or the
+	// Application
+	// +optional
+	Required bool `json:"required,omitempty"`
+	// Default is used when no value is supplied
+	// +optional
+	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+}
+
+// TemplateEngine selects how an ObjectTemplate body is rendered.
+// +kubebuilder:validation:Enum=GoTemplate;Substitution
+type TemplateEngine string
+
+const (
+	// GoTemplateEngine renders the body as a Go text/template.
+	GoTemplateEngine TemplateEngine = "GoTemplate"
+
+	// SubstitutionEngine parses the body as YAML and replaces ${name}
+	// placeholders in its values.
+	SubstitutionEngine TemplateEngine = "Substitution"
+)
+
 // ObjectTemplateStatus defines the observed state of ObjectTemplate.
 type ObjectTemplateStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -61,10 +159,25 @@ type ObjectTemplateStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Variables the template body references, whether or not they are
+	// declared in spec.variables
+	// +optional
+	ReferencedVariables []string `json:"referencedVariables,omitempty"`
+
+	// ApplicationTemplates that list this ObjectTemplate
+	// +optional
+	ApplicationTemplates []string `json:"applicationTemplates,omitempty"`
 }
 
+// ValidCondition is True when the body of an ObjectTemplate parses with its
+// engine.
+const ValidCondition = "Valid"
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
 
 // ObjectTemplate is the Schema for the objecttemplates API
 type ObjectTemplate struct {
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GitRef selects the revision of a Git repository to read. When more than one
+// field is set the most specific wins: commit, then tag, then branch. The
+// default branch of the repository is used when none are set.
+type GitRef struct {
+	// +optional
+	Branch string `json:"branch,omitempty"`
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[0-9a-f]{40}$`
+	// +optional
+	Commit string `json:"commit,omitempty"`
+}
+
+// TemplateSourceSpec defines the desired state of TemplateSource
+type TemplateSourceSpec struct {
+	// URL of the Git repository, for example https://, file:// or the path of
+	// a local repository
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Revision to read
+	// +optional
+	Ref GitRef `json:"ref,omitempty,omitzero"`
+
+	// Directory within the repository to read templates from, the repository
+	// root when empty. YAML files are read recursively.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// How often the repository is fetched
+	// +kubebuilder:default="5m"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty,omitzero"`
+}
+
+// TemplateSourceStatus defines the observed state of TemplateSource.
+type TemplateSourceStatus struct {
+	// Commit the templates were last read from
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// Generation of the TemplateSource last fetched
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the TemplateSource resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Ready": the templates of the current commit have been applied
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.url`
+// +kubebuilder:printcolumn:name="Commit",type=string,JSONPath=`.status.commit`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+
+// TemplateSource is the Schema for the templatesources API. It reads
+// ObjectTemplates and ApplicationTemplates from a Git repository and keeps
+// them in its namespace.
+type TemplateSource struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of TemplateSource
+	// +required
+	Spec TemplateSourceSpec `json:"spec"`
+
+	// status defines the observed state of TemplateSource
+	// +optional
+	Status TemplateSourceStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// TemplateSourceList contains a list of TemplateSource
+type TemplateSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemplateSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TemplateSource{}, &TemplateSourceList{})
+}
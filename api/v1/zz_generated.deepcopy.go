@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -94,6 +95,13 @@ func (in *ApplicationObject) DeepCopyInto(out *ApplicationObject) {
 			(*out)[key] = val
 		}
 	}
+	if in.IgnoreDifferences != nil {
+		in, out := &in.IgnoreDifferences, &out.IgnoreDifferences
+		*out = make([]IgnoreDifference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationObject.
@@ -106,6 +114,26 @@ func (in *ApplicationObject) DeepCopy() *ApplicationObject {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationRevision) DeepCopyInto(out *ApplicationRevision) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]TemplateRevision, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationRevision.
+func (in *ApplicationRevision) DeepCopy() *ApplicationRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 	*out = *in
@@ -116,6 +144,26 @@ func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]Patch, len(*in))
+		copy(*out, *in)
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RollbackTo != nil {
+		in, out := &in.RollbackTo, &out.RollbackTo
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSpec.
@@ -138,6 +186,62 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]ManagedObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]FieldConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedUpdates != nil {
+		in, out := &in.SkippedUpdates, &out.SkippedUpdates
+		*out = make([]ManagedObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retained != nil {
+		in, out := &in.Retained, &out.Retained
+		*out = make([]ManagedObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adopted != nil {
+		in, out := &in.Adopted, &out.Adopted
+		*out = make([]ManagedObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotAdopted != nil {
+		in, out := &in.NotAdopted, &out.NotAdopted
+		*out = make([]ManagedObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make([]AppliedManifest, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastResyncTime != nil {
+		in, out := &in.LastResyncTime, &out.LastResyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]ApplicationRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		**out = **in
+	}
+	if in.AutomaticRollback != nil {
+		in, out := &in.AutomaticRollback, &out.AutomaticRollback
+		*out = new(AutomaticRollback)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationStatus.
@@ -241,6 +345,16 @@ func (in *ApplicationTemplateStatus) DeepCopyInto(out *ApplicationTemplateStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Consumers != nil {
+		in, out := &in.Consumers, &out.Consumers
+		*out = make([]TemplateConsumer, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObjectTemplates != nil {
+		in, out := &in.ObjectTemplates, &out.ObjectTemplates
+		*out = make([]ObjectTemplateReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTemplateStatus.
@@ -253,6 +367,109 @@ func (in *ApplicationTemplateStatus) DeepCopy() *ApplicationTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedManifest) DeepCopyInto(out *AppliedManifest) {
+	*out = *in
+	out.ManagedObject = in.ManagedObject
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedManifest.
+func (in *AppliedManifest) DeepCopy() *AppliedManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutomaticRollback) DeepCopyInto(out *AutomaticRollback) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticRollback.
+func (in *AutomaticRollback) DeepCopy() *AutomaticRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(AutomaticRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldConflict) DeepCopyInto(out *FieldConflict) {
+	*out = *in
+	out.ManagedObject = in.ManagedObject
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldConflict.
+func (in *FieldConflict) DeepCopy() *FieldConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitRef) DeepCopyInto(out *GitRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRef.
+func (in *GitRef) DeepCopy() *GitRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GitRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnoreDifference) DeepCopyInto(out *IgnoreDifference) {
+	*out = *in
+	out.Target = in.Target
+	if in.JSONPointers != nil {
+		in, out := &in.JSONPointers, &out.JSONPointers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFieldsManagers != nil {
+		in, out := &in.ManagedFieldsManagers, &out.ManagedFieldsManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnoreDifference.
+func (in *IgnoreDifference) DeepCopy() *IgnoreDifference {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnoreDifference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedObject) DeepCopyInto(out *ManagedObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedObject.
+func (in *ManagedObject) DeepCopy() *ManagedObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectTemplate) DeepCopyInto(out *ObjectTemplate) {
 	*out = *in
@@ -312,6 +529,21 @@ func (in *ObjectTemplateList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateReference) DeepCopyInto(out *ObjectTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateReference.
+func (in *ObjectTemplateReference) DeepCopy() *ObjectTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectTemplateSpec) DeepCopyInto(out *ObjectTemplateSpec) {
 	*out = *in
@@ -320,6 +552,25 @@ func (in *ObjectTemplateSpec) DeepCopyInto(out *ObjectTemplateSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.VariableDefinitions != nil {
+		in, out := &in.VariableDefinitions, &out.VariableDefinitions
+		*out = make([]VariableDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IgnoreDifferences != nil {
+		in, out := &in.IgnoreDifferences, &out.IgnoreDifferences
+		*out = make([]IgnoreDifference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RecreateOnImmutableChange != nil {
+		in, out := &in.RecreateOnImmutableChange, &out.RecreateOnImmutableChange
+		*out = new(RecreateOnImmutableChange)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSpec.
@@ -342,6 +593,16 @@ func (in *ObjectTemplateStatus) DeepCopyInto(out *ObjectTemplateStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReferencedVariables != nil {
+		in, out := &in.ReferencedVariables, &out.ReferencedVariables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicationTemplates != nil {
+		in, out := &in.ApplicationTemplates, &out.ApplicationTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateStatus.
@@ -353,3 +614,212 @@ func (in *ObjectTemplateStatus) DeepCopy() *ObjectTemplateStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Patch) DeepCopyInto(out *Patch) {
+	*out = *in
+	out.Target = in.Target
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Patch.
+func (in *Patch) DeepCopy() *Patch {
+	if in == nil {
+		return nil
+	}
+	out := new(Patch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchTarget) DeepCopyInto(out *PatchTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchTarget.
+func (in *PatchTarget) DeepCopy() *PatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecreateOnImmutableChange) DeepCopyInto(out *RecreateOnImmutableChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecreateOnImmutableChange.
+func (in *RecreateOnImmutableChange) DeepCopy() *RecreateOnImmutableChange {
+	if in == nil {
+		return nil
+	}
+	out := new(RecreateOnImmutableChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackStatus.
+func (in *RollbackStatus) DeepCopy() *RollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateConsumer) DeepCopyInto(out *TemplateConsumer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateConsumer.
+func (in *TemplateConsumer) DeepCopy() *TemplateConsumer {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateConsumer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateRevision) DeepCopyInto(out *TemplateRevision) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateRevision.
+func (in *TemplateRevision) DeepCopy() *TemplateRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateSource) DeepCopyInto(out *TemplateSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSource.
+func (in *TemplateSource) DeepCopy() *TemplateSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemplateSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateSourceList) DeepCopyInto(out *TemplateSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TemplateSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSourceList.
+func (in *TemplateSourceList) DeepCopy() *TemplateSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TemplateSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateSourceSpec) DeepCopyInto(out *TemplateSourceSpec) {
+	*out = *in
+	out.Ref = in.Ref
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSourceSpec.
+func (in *TemplateSourceSpec) DeepCopy() *TemplateSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateSourceStatus) DeepCopyInto(out *TemplateSourceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSourceStatus.
+func (in *TemplateSourceStatus) DeepCopy() *TemplateSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariableDefinition) DeepCopyInto(out *VariableDefinition) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariableDefinition.
+func (in *VariableDefinition) DeepCopy() *VariableDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(VariableDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// ConvertTo converts this Application to the Hub version (v1).
+func (src *Application) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.Application)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	removeData(dst)
+
+	dst.Spec.Template = src.Spec.TemplateRef.Name
+	dst.Spec.Variables = variablesToV1(src.Spec.Variables)
+	dst.Spec.Patches = patchesToV1(src.Spec.Patches)
+	dst.Spec.Adoption = v1.AdoptionPolicy(src.Spec.Adoption)
+	dst.Spec.RevisionHistoryLimit = copyPointer(src.Spec.RevisionHistoryLimit)
+	dst.Spec.RollbackTo = copyPointer(src.Spec.RollbackTo)
+	dst.Spec.ProgressDeadlineSeconds = copyPointer(src.Spec.ProgressDeadlineSeconds)
+	dst.Spec.Suspend = src.Spec.Suspend
+
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+	dst.Status.Inventory = inventoryToV1(src.Status.Inventory)
+	dst.Status.Conflicts = conflictsToV1(src.Status.Conflicts)
+	dst.Status.SkippedUpdates = inventoryToV1(src.Status.SkippedUpdates)
+	dst.Status.Retained = inventoryToV1(src.Status.Retained)
+	dst.Status.Adopted = inventoryToV1(src.Status.Adopted)
+	dst.Status.NotAdopted = inventoryToV1(src.Status.NotAdopted)
+	dst.Status.Manifests = manifestsToV1(src.Status.Manifests)
+	dst.Status.LastResyncTime = src.Status.LastResyncTime.DeepCopy()
+	dst.Status.CurrentRevision = src.Status.CurrentRevision
+	dst.Status.Revisions = revisionsToV1(src.Status.Revisions)
+	dst.Status.Rollback = nil
+	if r := src.Status.Rollback; r != nil {
+		dst.Status.Rollback = &v1.RollbackStatus{Revision: r.Revision, Generation: r.Generation}
+	}
+	dst.Status.LastHealthyRevision = src.Status.LastHealthyRevision
+	dst.Status.AutomaticRollback = nil
+	if r := src.Status.AutomaticRollback; r != nil {
+		dst.Status.AutomaticRollback = &v1.AutomaticRollback{Revision: r.Revision, From: r.From}
+	}
+
+	var restored ApplicationSpec
+	applicationSpecFromV1(&dst.Spec, nil, &restored)
+	if !equality.Semantic.DeepEqual(restored, src.Spec) {
+		return marshalData(dst, src.Spec)
+	}
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1) to this Application.
+func (dst *Application) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.Application)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	var saved ApplicationSpec
+	ok, err := unmarshalData(dst, &saved)
+	if err != nil {
+		return err
+	}
+	if ok {
+		applicationSpecFromV1(&src.Spec, &saved, &dst.Spec)
+	} else {
+		applicationSpecFromV1(&src.Spec, nil, &dst.Spec)
+	}
+
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+	dst.Status.Inventory = inventoryFromV1(src.Status.Inventory)
+	dst.Status.Conflicts = conflictsFromV1(src.Status.Conflicts)
+	dst.Status.SkippedUpdates = inventoryFromV1(src.Status.SkippedUpdates)
+	dst.Status.Retained = inventoryFromV1(src.Status.Retained)
+	dst.Status.Adopted = inventoryFromV1(src.Status.Adopted)
+	dst.Status.NotAdopted = inventoryFromV1(src.Status.NotAdopted)
+	dst.Status.Manifests = manifestsFromV1(src.Status.Manifests)
+	dst.Status.LastResyncTime = src.Status.LastResyncTime.DeepCopy()
+	dst.Status.CurrentRevision = src.Status.CurrentRevision
+	dst.Status.Revisions = revisionsFromV1(src.Status.Revisions)
+	dst.Status.Rollback = nil
+	if r := src.Status.Rollback; r != nil {
+		dst.Status.Rollback = &RollbackStatus{Revision: r.Revision, Generation: r.Generation}
+	}
+	dst.Status.LastHealthyRevision = src.Status.LastHealthyRevision
+	dst.Status.AutomaticRollback = nil
+	if r := src.Status.AutomaticRollback; r != nil {
+		dst.Status.AutomaticRollback = &AutomaticRollback{Revision: r.Revision, From: r.From}
+	}
+
+	return nil
+}
+
+func applicationSpecFromV1(in *v1.ApplicationSpec, saved *ApplicationSpec, out *ApplicationSpec) {
+	out.TemplateRef = ApplicationTemplateRef{Kind: "ApplicationTemplate", Name: in.Template}
+	out.Patches = patchesFromV1(in.Patches)
+	out.Adoption = AdoptionPolicy(in.Adoption)
+	out.RevisionHistoryLimit = copyPointer(in.RevisionHistoryLimit)
+	out.RollbackTo = copyPointer(in.RollbackTo)
+	out.ProgressDeadlineSeconds = copyPointer(in.ProgressDeadlineSeconds)
+	out.Suspend = in.Suspend
+	if saved == nil {
+		out.Variables = variablesFromV1(in.Variables, nil)
+		return
+	}
+	out.Variables = variablesFromV1(in.Variables, saved.Variables)
+}
+
+func patchesToV1(in []Patch) []v1.Patch {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1.Patch, len(in))
+	for i, p := range in {
+		out[i] = v1.Patch{
+			Target: v1.PatchTarget(p.Target),
+			Type:   v1.PatchType(p.Type),
+			Patch:  p.Patch,
+		}
+	}
+	return out
+}
+
+func patchesFromV1(in []v1.Patch) []Patch {
+	if in == nil {
+		return nil
+	}
+	out := make([]Patch, len(in))
+	for i, p := range in {
+		out[i] = Patch{
+			Target: PatchTarget(p.Target),
+			Type:   PatchType(p.Type),
+			Patch:  p.Patch,
+		}
+	}
+	return out
+}
+
+func inventoryToV1(in []ManagedObject) []v1.ManagedObject {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1.ManagedObject, len(in))
+	for i, o := range in {
+		out[i] = v1.ManagedObject(o)
+	}
+	return out
+}
+
+func inventoryFromV1(in []v1.ManagedObject) []ManagedObject {
+	if in == nil {
+		return nil
+	}
+	out := make([]ManagedObject, len(in))
+	for i, o := range in {
+		out[i] = ManagedObject(o)
+	}
+	return out
+}
+
+func manifestsToV1(in []AppliedManifest) []v1.AppliedManifest {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1.AppliedManifest, len(in))
+	for i, m := range in {
+		out[i] = v1.AppliedManifest{
+			ManagedObject:   v1.ManagedObject(m.ManagedObject),
+			Hash:            m.Hash,
+			Generation:      m.Generation,
+			ResourceVersion: m.ResourceVersion,
+		}
+	}
+	return out
+}
+
+func manifestsFromV1(in []v1.AppliedManifest) []AppliedManifest {
+	if in == nil {
+		return nil
+	}
+	out := make([]AppliedManifest, len(in))
+	for i, m := range in {
+		out[i] = AppliedManifest{
+			ManagedObject:   ManagedObject(m.ManagedObject),
+			Hash:            m.Hash,
+			Generation:      m.Generation,
+			ResourceVersion: m.ResourceVersion,
+		}
+	}
+	return out
+}
+
+func revisionsToV1(in []ApplicationRevision) []v1.ApplicationRevision {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1.ApplicationRevision, len(in))
+	for i, r := range in {
+		out[i] = v1.ApplicationRevision{Revision: r.Revision, Name: r.Name}
+		if r.Templates != nil {
+			out[i].Templates = make([]v1.TemplateRevision, len(r.Templates))
+			for j, t := range r.Templates {
+				out[i].Templates[j] = v1.TemplateRevision(t)
+			}
+		}
+	}
+	return out
+}
+
+func revisionsFromV1(in []v1.ApplicationRevision) []ApplicationRevision {
+	if in == nil {
+		return nil
+	}
+	out := make([]ApplicationRevision, len(in))
+	for i, r := range in {
+		out[i] = ApplicationRevision{Revision: r.Revision, Name: r.Name}
+		if r.Templates != nil {
+			out[i].Templates = make([]TemplateRevision, len(r.Templates))
+			for j, t := range r.Templates {
+				out[i].Templates[j] = TemplateRevision(t)
+			}
+		}
+	}
+	return out
+}
+
+func conflictsToV1(in []FieldConflict) []v1.FieldConflict {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1.FieldConflict, len(in))
+	for i, c := range in {
+		out[i] = v1.FieldConflict{
+			ManagedObject: v1.ManagedObject(c.ManagedObject),
+			Field:         c.Field,
+			Manager:       c.Manager,
+			Policy:        v1.ConflictPolicy(c.Policy),
+		}
+	}
+	return out
+}
+
+func conflictsFromV1(in []v1.FieldConflict) []FieldConflict {
+	if in == nil {
+		return nil
+	}
+	out := make([]FieldConflict, len(in))
+	for i, c := range in {
+		out[i] = FieldConflict{
+			ManagedObject: ManagedObject(c.ManagedObject),
+			Field:         c.Field,
+			Manager:       c.Manager,
+			Policy:        ConflictPolicy(c.Policy),
+		}
+	}
+	return out
+}
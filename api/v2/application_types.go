@@ -0,0 +1,390 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationTemplateRef identifies an ApplicationTemplate in the same
+// namespace as the Application.
+type ApplicationTemplateRef struct {
+	// kind of the referenced template.
+	// +kubebuilder:validation:Enum=ApplicationTemplate
+	// +kubebuilder:default=ApplicationTemplate
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// name of the referenced template.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+}
+
+// ApplicationSpec defines the desired state of Application
+type ApplicationSpec struct {
+	// templateRef references the ApplicationTemplate rendered for this application.
+	// +required
+	TemplateRef ApplicationTemplateRef `json:"templateRef"`
+
+	// variables are passed to every object rendered for this application and
+	// take precedence over the values set on the ApplicationTemplate. Values
+	// may be any JSON type.
+	// +optional
+	Variables map[string]apiextensionsv1.JSON `json:"variables,omitempty"`
+
+	// patches are applied, in order, to the rendered objects before they are
+	// applied to the cluster.
+	// +optional
+	Patches []Patch `json:"patches,omitempty"`
+
+	// adoption decides which existing objects braid takes over when it
+	// renders an object that is not controlled by this application.
+	// +kubebuilder:default=Orphaned
+	// +optional
+	Adoption AdoptionPolicy `json:"adoption,omitempty"`
+
+	// revisionHistoryLimit is the number of revisions of the rendered
+	// manifests kept to roll back to.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// rollbackTo is a revision whose manifests are applied instead of
+	// rendering the templates, until the spec changes again.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RollbackTo *int64 `json:"rollbackTo,omitempty"`
+
+	// progressDeadlineSeconds is how long the objects of a revision may take
+	// to become healthy. A revision that is still progressing after it, or
+	// whose objects are degraded, is rolled back to the last healthy
+	// revision. Revisions are not rolled back when it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// suspend stops rendering and applying the templates. Objects already
+	// applied are left as they are until the Application is resumed or
+	// deleted.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// AdoptionPolicy decides whether braid takes over a rendered object that
+// already exists without being controlled by the Application. Objects
+// controlled by another owner are never adopted.
+// +kubebuilder:validation:Enum=Never;Orphaned;Always
+type AdoptionPolicy string
+
+const (
+	// NeverAdoptionPolicy leaves existing objects unchanged.
+	NeverAdoptionPolicy AdoptionPolicy = "Never"
+	// OrphanedAdoptionPolicy adopts objects orphaned by an Application of
+	// the same name.
+	OrphanedAdoptionPolicy AdoptionPolicy = "Orphaned"
+	// AlwaysAdoptionPolicy adopts every object without a controller.
+	AlwaysAdoptionPolicy AdoptionPolicy = "Always"
+)
+
+// PatchType is the format of a Patch.
+// +kubebuilder:validation:Enum=StrategicMerge;JSON6902
+type PatchType string
+
+const (
+	// StrategicMergePatchType merges a partial object into the rendered object.
+	// Kinds built into Kubernetes use strategic merge semantics, other kinds
+	// use JSON merge patch (RFC 7386) semantics.
+	StrategicMergePatchType PatchType = "StrategicMerge"
+
+	// JSON6902PatchType applies a list of JSON patch (RFC 6902) operations.
+	JSON6902PatchType PatchType = "JSON6902"
+)
+
+// PatchTarget selects the rendered objects a Patch applies to. Fields left
+// empty match every object.
+type PatchTarget struct {
+	// group of the objects to patch.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// version of the objects to patch.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// kind of the objects to patch.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// name of the object to patch.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// Patch modifies rendered objects of an Application.
+type Patch struct {
+	// target selects the objects to patch. Every rendered object is patched
+	// when it is empty.
+	// +optional
+	Target PatchTarget `json:"target,omitempty,omitzero"`
+
+	// type of the patch document.
+	// +kubebuilder:default=StrategicMerge
+	// +optional
+	Type PatchType `json:"type,omitempty"`
+
+	// patch is the patch document, as YAML or JSON.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Patch string `json:"patch"`
+}
+
+// ApplicationStatus defines the observed state of Application.
+type ApplicationStatus struct {
+	// conditions represent the current state of the Application resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "Degraded": the resource failed to reach or maintain its desired state
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// inventory lists the objects applied for this Application. Objects that
+	// are no longer rendered are pruned using it.
+	// +optional
+	Inventory []ManagedObject `json:"inventory,omitempty"`
+
+	// conflicts lists the fields of rendered objects that another field
+	// manager also manages, as found by the last reconcile.
+	// +optional
+	Conflicts []FieldConflict `json:"conflicts,omitempty"`
+
+	// skippedUpdates lists the objects with the CreateOnly apply strategy
+	// that differ from their rendered manifest and were left unchanged by the
+	// last reconcile.
+	// +optional
+	SkippedUpdates []ManagedObject `json:"skippedUpdates,omitempty"`
+
+	// retained lists the objects with the Retain deletion policy that are no
+	// longer rendered, or belong to an Application being deleted, waiting to
+	// be annotated with braid.james-parker.dev/allow-deletion=true.
+	// +optional
+	Retained []ManagedObject `json:"retained,omitempty"`
+
+	// adopted lists the existing objects braid took over for this
+	// application.
+	// +optional
+	Adopted []ManagedObject `json:"adopted,omitempty"`
+
+	// notAdopted lists the existing objects that are rendered for this
+	// application but were left unchanged by the last reconcile, because the
+	// adoption policy does not allow taking them over.
+	// +optional
+	NotAdopted []ManagedObject `json:"notAdopted,omitempty"`
+
+	// manifests records the hash of the manifest last applied for each
+	// object, used to skip applying objects that have not changed.
+	// +optional
+	Manifests []AppliedManifest `json:"manifests,omitempty"`
+
+	// lastResyncTime is when every object was last applied whether or not
+	// it changed.
+	// +optional
+	LastResyncTime *metav1.Time `json:"lastResyncTime,omitempty"`
+
+	// currentRevision is the revision of the rendered manifests last
+	// applied.
+	// +optional
+	CurrentRevision int64 `json:"currentRevision,omitempty"`
+
+	// revisions lists the revisions of the rendered manifests kept to roll
+	// back to, oldest first.
+	// +optional
+	Revisions []ApplicationRevision `json:"revisions,omitempty"`
+
+	// rollback is the rollback last requested by spec.rollbackTo.
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+
+	// lastHealthyRevision is the last revision whose objects were all
+	// healthy.
+	// +optional
+	LastHealthyRevision int64 `json:"lastHealthyRevision,omitempty"`
+
+	// automaticRollback is the rollback to the last healthy revision made
+	// because a revision was degraded.
+	// +optional
+	AutomaticRollback *AutomaticRollback `json:"automaticRollback,omitempty"`
+}
+
+// AutomaticRollback records a rollback from a degraded revision to the last
+// healthy one. It lasts while the templates render the degraded revision.
+type AutomaticRollback struct {
+	// revision rolled back to.
+	// +required
+	Revision int64 `json:"revision"`
+
+	// from is the degraded revision rolled back from.
+	// +required
+	From int64 `json:"from"`
+}
+
+// ApplicationRevision is a revision of the rendered manifests of an
+// Application, stored in a ControllerRevision with the variables and
+// manifests it was rendered with.
+type ApplicationRevision struct {
+	// revision is the number of the revision.
+	// +required
+	Revision int64 `json:"revision"`
+
+	// name of the ControllerRevision.
+	// +required
+	Name string `json:"name"`
+
+	// templates lists the templates the manifests were rendered from.
+	// +optional
+	Templates []TemplateRevision `json:"templates,omitempty"`
+}
+
+// TemplateRevision is the resourceVersion of a template an Application was
+// rendered from.
+type TemplateRevision struct {
+	// kind of the template.
+	// +required
+	Kind string `json:"kind"`
+
+	// name of the template.
+	// +required
+	Name string `json:"name"`
+
+	// resourceVersion of the template.
+	// +required
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// RollbackStatus records a rollback requested by spec.rollbackTo. The
+// rollback is in effect while the generation of the Application is the one
+// it was requested at.
+type RollbackStatus struct {
+	// revision rolled back to.
+	// +required
+	Revision int64 `json:"revision"`
+
+	// generation of the Application the rollback was requested at.
+	// +required
+	Generation int64 `json:"generation"`
+}
+
+// ManagedObject identifies an object rendered for an Application.
+type ManagedObject struct {
+	// apiVersion of the object.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// kind of the object.
+	// +required
+	Kind string `json:"kind"`
+
+	// namespace of the object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object.
+	// +required
+	Name string `json:"name"`
+}
+
+// FieldConflict is a field of a rendered object that another field manager
+// owns.
+type FieldConflict struct {
+	ManagedObject `json:",inline"`
+
+	// field is the path of the conflicting field, as reported by the API
+	// server.
+	// +required
+	Field string `json:"field"`
+
+	// manager is the name of the field manager that owns the field.
+	// +required
+	Manager string `json:"manager"`
+
+	// policy is the conflict policy that was applied.
+	// +required
+	Policy ConflictPolicy `json:"policy"`
+}
+
+// AppliedManifest records the manifest braid last applied for an object.
+type AppliedManifest struct {
+	ManagedObject `json:",inline"`
+
+	// hash is the SHA-256 of the rendered manifest, also stored on the
+	// object in the braid.james-parker.dev/manifest-hash annotation.
+	// +required
+	Hash string `json:"hash"`
+
+	// generation is the metadata.generation of the object once the manifest
+	// was applied.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// resourceVersion is the metadata.resourceVersion of the object once the
+	// manifest was applied, compared instead of the generation for kinds
+	// that do not have one.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Application is the Schema for the applications API
+type Application struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of Application
+	// +required
+	Spec ApplicationSpec `json:"spec"`
+
+	// status defines the observed state of Application
+	// +optional
+	Status ApplicationStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationList contains a list of Application
+type ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Application `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Application{}, &ApplicationList{})
+}
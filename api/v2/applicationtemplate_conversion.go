@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// ConvertTo converts this ApplicationTemplate to the Hub version (v1).
+func (src *ApplicationTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.ApplicationTemplate)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	removeData(dst)
+
+	dst.Spec.Objects = nil
+	if src.Spec.Objects != nil {
+		dst.Spec.Objects = make([]v1.ApplicationObject, len(src.Spec.Objects))
+		for i, o := range src.Spec.Objects {
+			dst.Spec.Objects[i] = v1.ApplicationObject{
+				Template:       o.TemplateRef.Name,
+				Variables:      variablesToV1(o.Variables),
+				ConflictPolicy: v1.ConflictPolicy(o.ConflictPolicy),
+				ApplyStrategy:  v1.ApplyStrategy(o.ApplyStrategy),
+
+				IgnoreDifferences: ignoreDifferencesToV1(o.IgnoreDifferences),
+				DeletionPolicy:    v1.DeletionPolicy(o.DeletionPolicy),
+			}
+		}
+	}
+
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+	dst.Status.Applications = src.Status.Applications
+	dst.Status.ReadyApplications = src.Status.ReadyApplications
+	dst.Status.DegradedApplications = src.Status.DegradedApplications
+	dst.Status.SuspendedApplications = src.Status.SuspendedApplications
+	dst.Status.Consumers = nil
+	if src.Status.Consumers != nil {
+		dst.Status.Consumers = make([]v1.TemplateConsumer, len(src.Status.Consumers))
+		for i, c := range src.Status.Consumers {
+			dst.Status.Consumers[i] = v1.TemplateConsumer(c)
+		}
+	}
+	dst.Status.ObjectTemplates = nil
+	if src.Status.ObjectTemplates != nil {
+		dst.Status.ObjectTemplates = make([]v1.ObjectTemplateReference, len(src.Status.ObjectTemplates))
+		for i, o := range src.Status.ObjectTemplates {
+			dst.Status.ObjectTemplates[i] = v1.ObjectTemplateReference(o)
+		}
+	}
+
+	var restored ApplicationTemplateSpec
+	applicationTemplateSpecFromV1(&dst.Spec, nil, &restored)
+	if !equality.Semantic.DeepEqual(restored, src.Spec) {
+		return marshalData(dst, src.Spec)
+	}
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1) to this ApplicationTemplate.
+func (dst *ApplicationTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.ApplicationTemplate)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	var saved ApplicationTemplateSpec
+	ok, err := unmarshalData(dst, &saved)
+	if err != nil {
+		return err
+	}
+	if ok {
+		applicationTemplateSpecFromV1(&src.Spec, &saved, &dst.Spec)
+	} else {
+		applicationTemplateSpecFromV1(&src.Spec, nil, &dst.Spec)
+	}
+
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+	dst.Status.Applications = src.Status.Applications
+	dst.Status.ReadyApplications = src.Status.ReadyApplications
+	dst.Status.DegradedApplications = src.Status.DegradedApplications
+	dst.Status.SuspendedApplications = src.Status.SuspendedApplications
+	dst.Status.Consumers = nil
+	if src.Status.Consumers != nil {
+		dst.Status.Consumers = make([]TemplateConsumer, len(src.Status.Consumers))
+		for i, c := range src.Status.Consumers {
+			dst.Status.Consumers[i] = TemplateConsumer(c)
+		}
+	}
+	dst.Status.ObjectTemplates = nil
+	if src.Status.ObjectTemplates != nil {
+		dst.Status.ObjectTemplates = make([]ObjectTemplateReference, len(src.Status.ObjectTemplates))
+		for i, o := range src.Status.ObjectTemplates {
+			dst.Status.ObjectTemplates[i] = ObjectTemplateReference(o)
+		}
+	}
+
+	return nil
+}
+
+func applicationTemplateSpecFromV1(in *v1.ApplicationTemplateSpec, saved *ApplicationTemplateSpec, out *ApplicationTemplateSpec) {
+	if in.Objects == nil {
+		out.Objects = nil
+		return
+	}
+	out.Objects = make([]ApplicationObject, len(in.Objects))
+	for i, o := range in.Objects {
+		var previous *ApplicationObject
+		if saved != nil && i < len(saved.Objects) && saved.Objects[i].TemplateRef.Name == o.Template {
+			previous = &saved.Objects[i]
+		}
+		out.Objects[i] = applicationObjectFromV1(&o, previous)
+	}
+}
+
+func applicationObjectFromV1(in *v1.ApplicationObject, saved *ApplicationObject) ApplicationObject {
+	out := ApplicationObject{
+		TemplateRef:    ObjectTemplateRef{Kind: "ObjectTemplate", Name: in.Template},
+		ConflictPolicy: ConflictPolicy(in.ConflictPolicy),
+		ApplyStrategy:  ApplyStrategy(in.ApplyStrategy),
+
+		IgnoreDifferences: ignoreDifferencesFromV1(in.IgnoreDifferences),
+		DeletionPolicy:    DeletionPolicy(in.DeletionPolicy),
+	}
+	if saved == nil {
+		out.Variables = variablesFromV1(in.Variables, nil)
+		return out
+	}
+	out.Variables = variablesFromV1(in.Variables, saved.Variables)
+	return out
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationTemplateSpec defines the desired state of ApplicationTemplate
+type ApplicationTemplateSpec struct {
+	// objects lists the ObjectTemplates rendered for every Application using
+	// this template.
+	// +optional
+	Objects []ApplicationObject `json:"objects,omitempty"`
+}
+
+// ApplicationObject is a single ObjectTemplate rendered as part of an
+// ApplicationTemplate.
+type ApplicationObject struct {
+	// templateRef references the ObjectTemplate to render.
+	// +required
+	TemplateRef ObjectTemplateRef `json:"templateRef"`
+
+	// variables are the default values passed to the ObjectTemplate. They are
+	// overridden by the variables set on the Application.
+	// +optional
+	Variables map[string]apiextensionsv1.JSON `json:"variables,omitempty"`
+
+	// conflictPolicy decides how server-side apply conflicts with other field
+	// managers are handled for the objects rendered from this template.
+	// +kubebuilder:default=Fail
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// applyStrategy decides how the objects rendered from this template are
+	// created and updated.
+	// +kubebuilder:default=ServerSideApply
+	// +optional
+	ApplyStrategy ApplyStrategy `json:"applyStrategy,omitempty"`
+
+	// ignoreDifferences lists fields of the objects rendered from this
+	// template braid leaves alone once they exist, in addition to those
+	// ignored by the ObjectTemplate.
+	// +optional
+	IgnoreDifferences []IgnoreDifference `json:"ignoreDifferences,omitempty"`
+
+	// deletionPolicy decides what happens to the objects rendered from this
+	// template when they are pruned or the Application is deleted.
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// DeletionPolicy decides what happens to a rendered object when braid no
+// longer manages it.
+// +kubebuilder:validation:Enum=Delete;Orphan;Retain
+type DeletionPolicy string
+
+const (
+	// DeleteDeletionPolicy deletes the object.
+	DeleteDeletionPolicy DeletionPolicy = "Delete"
+	// OrphanDeletionPolicy leaves the object in the cluster without the
+	// owner reference to the Application, labelled for later adoption.
+	OrphanDeletionPolicy DeletionPolicy = "Orphan"
+	// RetainDeletionPolicy keeps the object until it is annotated with
+	// braid.james-parker.dev/allow-deletion=true.
+	RetainDeletionPolicy DeletionPolicy = "Retain"
+)
+
+// ApplyStrategy decides how braid writes a rendered object.
+// +kubebuilder:validation:Enum=CreateOnly;ServerSideApply;Replace;MergePatch
+type ApplyStrategy string
+
+const (
+	// CreateOnlyStrategy creates the object if it is missing and never
+	// updates it.
+	CreateOnlyStrategy ApplyStrategy = "CreateOnly"
+	// ServerSideApplyStrategy applies the object with server-side apply.
+	ServerSideApplyStrategy ApplyStrategy = "ServerSideApply"
+	// ReplaceStrategy replaces the whole object with an update, dropping
+	// fields set by anyone else.
+	ReplaceStrategy ApplyStrategy = "Replace"
+	// MergePatchStrategy updates the object with a JSON merge patch.
+	MergePatchStrategy ApplyStrategy = "MergePatch"
+)
+
+// ConflictPolicy decides what happens when another field manager owns a field
+// braid applies.
+// +kubebuilder:validation:Enum=Fail;Force;SkipField
+type ConflictPolicy string
+
+const (
+	// FailConflictPolicy leaves the object unchanged and fails the reconcile.
+	FailConflictPolicy ConflictPolicy = "Fail"
+	// ForceConflictPolicy takes ownership of the conflicting fields.
+	ForceConflictPolicy ConflictPolicy = "Force"
+	// SkipFieldConflictPolicy applies the object without the conflicting
+	// fields, leaving them to their current manager.
+	SkipFieldConflictPolicy ConflictPolicy = "SkipField"
+)
+
+// ObjectTemplateRef identifies an ObjectTemplate in the same namespace as
+// the ApplicationTemplate.
+type ObjectTemplateRef struct {
+	// kind of the referenced template.
+	// +kubebuilder:validation:Enum=ObjectTemplate
+	// +kubebuilder:default=ObjectTemplate
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// name of the referenced template.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+}
+
+// ApplicationTemplateStatus defines the observed state of ApplicationTemplate.
+type ApplicationTemplateStatus struct {
+	// conditions represent the current state of the ApplicationTemplate resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "Degraded": the resource failed to reach or maintain its desired state
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// applications is the number of Applications using the template.
+	// +optional
+	Applications int32 `json:"applications,omitempty"`
+
+	// readyApplications is the number of Applications using the template
+	// whose Ready condition is True.
+	// +optional
+	ReadyApplications int32 `json:"readyApplications,omitempty"`
+
+	// degradedApplications is the number of Applications using the template
+	// whose Degraded condition is True.
+	// +optional
+	DegradedApplications int32 `json:"degradedApplications,omitempty"`
+
+	// suspendedApplications is the number of Applications using the template
+	// that are suspended.
+	// +optional
+	SuspendedApplications int32 `json:"suspendedApplications,omitempty"`
+
+	// consumers lists the Applications using the template and the revision
+	// of it they run.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Consumers []TemplateConsumer `json:"consumers,omitempty"`
+
+	// objectTemplates lists the ObjectTemplates referenced by objects and
+	// whether they exist.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ObjectTemplates []ObjectTemplateReference `json:"objectTemplates,omitempty"`
+}
+
+// TemplateConsumer is an Application using an ApplicationTemplate.
+type TemplateConsumer struct {
+	// name of the Application.
+	// +required
+	Name string `json:"name"`
+
+	// revision of the Application currently applied.
+	// +optional
+	Revision int64 `json:"revision,omitempty"`
+
+	// templateResourceVersion is the resource version of the
+	// ApplicationTemplate the current revision of the Application was
+	// rendered from.
+	// +optional
+	TemplateResourceVersion string `json:"templateResourceVersion,omitempty"`
+}
+
+// ObjectTemplateReference is an ObjectTemplate referenced by an
+// ApplicationTemplate.
+type ObjectTemplateReference struct {
+	// name of the ObjectTemplate.
+	// +required
+	Name string `json:"name"`
+
+	// found is true when the ObjectTemplate exists.
+	// +required
+	Found bool `json:"found"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Applications",type=integer,JSONPath=`.status.applications`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyApplications`
+// +kubebuilder:printcolumn:name="Degraded",type=integer,JSONPath=`.status.degradedApplications`
+// +kubebuilder:printcolumn:name="Suspended",type=integer,JSONPath=`.status.suspendedApplications`
+
+// ApplicationTemplate is the Schema for the applicationtemplates API
+type ApplicationTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of ApplicationTemplate
+	// +required
+	Spec ApplicationTemplateSpec `json:"spec"`
+
+	// status defines the observed state of ApplicationTemplate
+	// +optional
+	Status ApplicationTemplateStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ApplicationTemplateList contains a list of ApplicationTemplate
+type ApplicationTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApplicationTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApplicationTemplate{}, &ApplicationTemplateList{})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// conversionDataAnnotation stores the parts of a v2 object that cannot be
+// represented in v1, so that a v2 -> v1 -> v2 round trip is lossless.
+const conversionDataAnnotation = "braid.james-parker.dev/v2-conversion-data"
+
+// marshalData records data on obj in the conversion data annotation.
+func marshalData(obj metav1.Object, data any) error {
+	// HTML escaping would rewrite string variables such as "<" on the way
+	// through the annotation.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(data); err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[conversionDataAnnotation] = strings.TrimSuffix(buf.String(), "\n")
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// unmarshalData reads the conversion data annotation from obj into data and
+// removes the annotation. It reports whether the annotation was present.
+func unmarshalData(obj metav1.Object, data any) (bool, error) {
+	annotations := obj.GetAnnotations()
+	raw, ok := annotations[conversionDataAnnotation]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(raw), data); err != nil {
+		return false, err
+	}
+	removeData(obj)
+	return true, nil
+}
+
+// removeData drops the conversion data annotation from obj.
+func removeData(obj metav1.Object) {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[conversionDataAnnotation]; !ok {
+		return
+	}
+	delete(annotations, conversionDataAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// variableToString renders a typed variable as the string v1 expects. JSON
+// strings are unquoted, every other value is kept as compact JSON.
+func variableToString(v apiextensionsv1.JSON) string {
+	var s string
+	if err := json.Unmarshal(v.Raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, v.Raw); err != nil {
+		return string(v.Raw)
+	}
+	return buf.String()
+}
+
+// stringToVariable wraps a v1 string variable as a JSON string.
+func stringToVariable(s string) apiextensionsv1.JSON {
+	raw, _ := json.Marshal(s)
+	return apiextensionsv1.JSON{Raw: raw}
+}
+
+func variablesToV1(in map[string]apiextensionsv1.JSON) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = variableToString(v)
+	}
+	return out
+}
+
+// variablesFromV1 converts v1 variables, restoring the typed value from saved
+// whenever it still matches the v1 string.
+func variablesFromV1(in map[string]string, saved map[string]apiextensionsv1.JSON) map[string]apiextensionsv1.JSON {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]apiextensionsv1.JSON, len(in))
+	for k, v := range in {
+		if typed, ok := saved[k]; ok && variableToString(typed) == v {
+			out[k] = *typed.DeepCopy()
+			continue
+		}
+		out[k] = stringToVariable(v)
+	}
+	return out
+}
+
+func copyConditions(in []metav1.Condition) []metav1.Condition {
+	if in == nil {
+		return nil
+	}
+	out := make([]metav1.Condition, len(in))
+	for i := range in {
+		in[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
+
+func copyPointer[T any](in *T) *T {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/randfill"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+const fuzzIterations = 1000
+
+// filler produces random but API-valid objects for the round trip tests.
+func filler(seed int64) *randfill.Filler {
+	return randfill.NewWithSeed(seed).
+		NilChance(0.2).
+		NumElements(0, 3).
+		Funcs(
+			// TypeMeta is set by the API server, not by conversion.
+			func(*metav1.TypeMeta, randfill.Continue) {},
+			func(m *metav1.ObjectMeta, c randfill.Continue) {
+				m.Name = c.String(10)
+				m.Namespace = c.String(10)
+				if c.Bool() {
+					m.Annotations = map[string]string{c.String(5): c.String(5)}
+				}
+			},
+			func(t *metav1.Time, c randfill.Continue) {
+				*t = metav1.Unix(c.Int63n(1<<32), 0)
+			},
+			// Each reference only admits the one kind braid renders.
+			func(r *ApplicationTemplateRef, c randfill.Continue) {
+				r.Kind = "ApplicationTemplate"
+				r.Name = c.String(10)
+			},
+			func(r *ObjectTemplateRef, c randfill.Continue) {
+				r.Kind = "ObjectTemplate"
+				r.Name = c.String(10)
+			},
+			// v1 only defines variables it lists, and only those that are
+			// not plain optional strings.
+			func(s *v1.ObjectTemplateSpec, c randfill.Continue) {
+				c.FillNoCustom(s)
+				s.VariableDefinitions = nil
+				for _, name := range s.Variables {
+					if c.Bool() {
+						continue
+					}
+					d := v1.VariableDefinition{}
+					c.Fill(&d)
+					d.Name = name
+					d.Required = true
+					s.VariableDefinitions = append(s.VariableDefinitions, d)
+				}
+			},
+			func(j *apiextensionsv1.JSON, c randfill.Continue) {
+				values := []string{
+					string(must(json.Marshal(c.String(10)))),
+					fmt.Sprintf("%d", c.Int63()),
+					fmt.Sprintf("%t", c.Bool()),
+					fmt.Sprintf(`{"%s":%d}`, "key", c.Intn(100)),
+					fmt.Sprintf(`["%s",%t]`, "item", c.Bool()),
+				}
+				j.Raw = []byte(values[c.Intn(len(values))])
+			},
+		)
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// roundTrip checks hub -> spoke -> hub and spoke -> hub -> spoke conversions
+// are lossless for randomly filled objects.
+func roundTrip(t *testing.T, newHub func() conversion.Hub, newSpoke func() conversion.Convertible) {
+	g := NewWithT(t)
+
+	for i := range fuzzIterations {
+		f := filler(int64(i))
+
+		hub := newHub()
+		f.Fill(hub)
+		spoke := newSpoke()
+		g.Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		hubAfter := newHub()
+		g.Expect(spoke.ConvertTo(hubAfter)).To(Succeed())
+		g.Expect(equality.Semantic.DeepEqual(hub, hubAfter)).To(BeTrue(), cmp.Diff(hub, hubAfter))
+
+		spoke = newSpoke()
+		f.Fill(spoke)
+		hub = newHub()
+		g.Expect(spoke.ConvertTo(hub)).To(Succeed())
+		spokeAfter := newSpoke()
+		g.Expect(spokeAfter.ConvertFrom(hub)).To(Succeed())
+		g.Expect(equality.Semantic.DeepEqual(spoke, spokeAfter)).To(BeTrue(), cmp.Diff(spoke, spokeAfter))
+	}
+}
+
+func TestApplicationRoundTrip(t *testing.T) {
+	roundTrip(t,
+		func() conversion.Hub { return &v1.Application{} },
+		func() conversion.Convertible { return &Application{} })
+}
+
+func TestApplicationTemplateRoundTrip(t *testing.T) {
+	roundTrip(t,
+		func() conversion.Hub { return &v1.ApplicationTemplate{} },
+		func() conversion.Convertible { return &ApplicationTemplate{} })
+}
+
+func TestObjectTemplateRoundTrip(t *testing.T) {
+	roundTrip(t,
+		func() conversion.Hub { return &v1.ObjectTemplate{} },
+		func() conversion.Convertible { return &ObjectTemplate{} })
+}
+
+func TestApplicationConvertToHubStringifiesTypedVariables(t *testing.T) {
+	g := NewWithT(t)
+
+	src := &Application{
+		Spec: ApplicationSpec{
+			TemplateRef: ApplicationTemplateRef{Kind: "ApplicationTemplate", Name: "web"},
+			Variables: map[string]apiextensionsv1.JSON{
+				"image":    {Raw: []byte(`"nginx"`)},
+				"replicas": {Raw: []byte(`3`)},
+				"debug":    {Raw: []byte(`true`)},
+			},
+		},
+	}
+
+	dst := &v1.Application{}
+	g.Expect(src.ConvertTo(dst)).To(Succeed())
+	g.Expect(dst.Spec.Template).To(Equal("web"))
+	g.Expect(dst.Spec.Variables).To(Equal(map[string]string{
+		"image":    "nginx",
+		"replicas": "3",
+		"debug":    "true",
+	}))
+	g.Expect(dst.Annotations).To(HaveKey(conversionDataAnnotation))
+
+	back := &Application{}
+	g.Expect(back.ConvertFrom(dst)).To(Succeed())
+	g.Expect(back.Spec).To(Equal(src.Spec))
+	g.Expect(back.Annotations).NotTo(HaveKey(conversionDataAnnotation))
+}
+
+func TestApplicationConvertFromHubPrefersEditedV1Values(t *testing.T) {
+	g := NewWithT(t)
+
+	src := &Application{
+		Spec: ApplicationSpec{
+			TemplateRef: ApplicationTemplateRef{Kind: "ApplicationTemplate", Name: "web"},
+			Variables:   map[string]apiextensionsv1.JSON{"replicas": {Raw: []byte(`3`)}},
+		},
+	}
+	hub := &v1.Application{}
+	g.Expect(src.ConvertTo(hub)).To(Succeed())
+
+	hub.Spec.Variables["replicas"] = "5"
+
+	dst := &Application{}
+	g.Expect(dst.ConvertFrom(hub)).To(Succeed())
+	g.Expect(string(dst.Spec.Variables["replicas"].Raw)).To(Equal(`"5"`))
+}
+
+func TestObjectTemplateConvertFromHubDefaultsVariableType(t *testing.T) {
+	g := NewWithT(t)
+
+	src := &v1.ObjectTemplate{
+		Spec: v1.ObjectTemplateSpec{
+			ApiVersion: "v1",
+			Kind:       "Pod",
+			Spec:       "containers: []",
+			Variables:  []string{"image"},
+		},
+	}
+
+	dst := &ObjectTemplate{}
+	g.Expect(dst.ConvertFrom(src)).To(Succeed())
+	g.Expect(dst.Spec.Source.Spec).To(Equal("containers: []"))
+	g.Expect(dst.Spec.Variables).To(Equal([]VariableDefinition{{Name: "image", Type: VariableTypeString}}))
+	g.Expect(dst.Annotations).To(BeNil())
+}
+
+func TestConvertFromHubSetsTemplateRefKinds(t *testing.T) {
+	g := NewWithT(t)
+
+	app := &Application{}
+	g.Expect(app.ConvertFrom(&v1.Application{Spec: v1.ApplicationSpec{Template: "web"}})).To(Succeed())
+	g.Expect(app.Spec.TemplateRef).To(Equal(ApplicationTemplateRef{Kind: "ApplicationTemplate", Name: "web"}))
+
+	tmpl := &ApplicationTemplate{}
+	g.Expect(tmpl.ConvertFrom(&v1.ApplicationTemplate{Spec: v1.ApplicationTemplateSpec{
+		Objects: []v1.ApplicationObject{{Template: "deployment"}},
+	}})).To(Succeed())
+	g.Expect(tmpl.Spec.Objects[0].TemplateRef).To(Equal(ObjectTemplateRef{Kind: "ObjectTemplate", Name: "deployment"}))
+}
+
+func TestObjectTemplateConvertToHubKeepsVariableDefinitions(t *testing.T) {
+	g := NewWithT(t)
+
+	src := &ObjectTemplate{
+		Spec: ObjectTemplateSpec{
+			Source: ObjectTemplateSource{Manifests: "apiVersion: v1\nkind: ConfigMap\n"},
+			Variables: []VariableDefinition{
+				{Name: "image", Type: VariableTypeString},
+				{Name: "replicas", Type: VariableTypeInteger, Required: true, Default: &apiextensionsv1.JSON{Raw: []byte(`2`)}},
+			},
+		},
+	}
+
+	dst := &v1.ObjectTemplate{}
+	g.Expect(src.ConvertTo(dst)).To(Succeed())
+	g.Expect(dst.Spec.Variables).To(Equal([]string{"image", "replicas"}))
+	g.Expect(dst.Spec.VariableDefinitions).To(Equal([]v1.VariableDefinition{
+		{Name: "replicas", Type: v1.VariableTypeInteger, Required: true, Default: &apiextensionsv1.JSON{Raw: []byte(`2`)}},
+	}))
+	g.Expect(dst.Annotations).NotTo(HaveKey(conversionDataAnnotation))
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// ConvertTo converts this ObjectTemplate to the Hub version (v1).
+func (src *ObjectTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.ObjectTemplate)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	removeData(dst)
+
+	dst.Spec.ApiVersion = src.Spec.APIVersion
+	dst.Spec.Kind = src.Spec.Kind
+	dst.Spec.Spec = src.Spec.Source.Spec
+	dst.Spec.Manifests = src.Spec.Source.Manifests
+	dst.Spec.Engine = v1.TemplateEngine(src.Spec.Source.Engine)
+	dst.Spec.Variables = nil
+	dst.Spec.VariableDefinitions = nil
+	if src.Spec.Variables != nil {
+		dst.Spec.Variables = make([]string, len(src.Spec.Variables))
+		for i, v := range src.Spec.Variables {
+			dst.Spec.Variables[i] = v.Name
+			if !plainVariable(v) {
+				dst.Spec.VariableDefinitions = append(dst.Spec.VariableDefinitions, v1.VariableDefinition{
+					Name:        v.Name,
+					Type:        v1.VariableType(v.Type),
+					Description: v.Description,
+					Required:    v.Required,
+					Default:     v.Default.DeepCopy(),
+				})
+			}
+		}
+	}
+
+	dst.Spec.IgnoreDifferences = ignoreDifferencesToV1(src.Spec.IgnoreDifferences)
+	dst.Spec.RecreateOnImmutableChange = nil
+	if r := src.Spec.RecreateOnImmutableChange; r != nil {
+		dst.Spec.RecreateOnImmutableChange = &v1.RecreateOnImmutableChange{WaitForDeletion: r.WaitForDeletion}
+	}
+
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+	dst.Status.ReferencedVariables = append([]string(nil), src.Status.ReferencedVariables...)
+	dst.Status.ApplicationTemplates = append([]string(nil), src.Status.ApplicationTemplates...)
+
+	var restored ObjectTemplateSpec
+	objectTemplateSpecFromV1(&dst.Spec, nil, &restored)
+	if !equality.Semantic.DeepEqual(restored, src.Spec) {
+		return marshalData(dst, src.Spec)
+	}
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1) to this ObjectTemplate.
+func (dst *ObjectTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.ObjectTemplate)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	var saved ObjectTemplateSpec
+	ok, err := unmarshalData(dst, &saved)
+	if err != nil {
+		return err
+	}
+	if ok {
+		objectTemplateSpecFromV1(&src.Spec, &saved, &dst.Spec)
+	} else {
+		objectTemplateSpecFromV1(&src.Spec, nil, &dst.Spec)
+	}
+
+	dst.Status.Conditions = copyConditions(src.Status.Conditions)
+	dst.Status.ReferencedVariables = append([]string(nil), src.Status.ReferencedVariables...)
+	dst.Status.ApplicationTemplates = append([]string(nil), src.Status.ApplicationTemplates...)
+
+	return nil
+}
+
+func objectTemplateSpecFromV1(in *v1.ObjectTemplateSpec, saved *ObjectTemplateSpec, out *ObjectTemplateSpec) {
+	out.APIVersion = in.ApiVersion
+	out.Kind = in.Kind
+	out.Source = ObjectTemplateSource{
+		Spec:      in.Spec,
+		Manifests: in.Manifests,
+		Engine:    TemplateEngine(in.Engine),
+	}
+	out.IgnoreDifferences = ignoreDifferencesFromV1(in.IgnoreDifferences)
+	out.RecreateOnImmutableChange = nil
+	if r := in.RecreateOnImmutableChange; r != nil {
+		out.RecreateOnImmutableChange = &RecreateOnImmutableChange{WaitForDeletion: r.WaitForDeletion}
+	}
+
+	if in.Variables == nil {
+		out.Variables = nil
+		return
+	}
+	definitions := variableDefinitionsFromV1(in)
+	out.Variables = make([]VariableDefinition, len(in.Variables))
+	for i, name := range in.Variables {
+		if d, ok := definitions[i]; ok {
+			out.Variables[i] = VariableDefinition{
+				Name:        d.Name,
+				Type:        VariableType(d.Type),
+				Description: d.Description,
+				Required:    d.Required,
+				Default:     d.Default.DeepCopy(),
+			}
+			continue
+		}
+		if saved != nil && i < len(saved.Variables) && saved.Variables[i].Name == name {
+			saved.Variables[i].DeepCopyInto(&out.Variables[i])
+			continue
+		}
+		out.Variables[i] = VariableDefinition{Name: name, Type: VariableTypeString}
+	}
+}
+
+// plainVariable reports whether v is an optional string variable without a
+// default, which v1 represents by its name alone.
+func plainVariable(v VariableDefinition) bool {
+	return v.Type == VariableTypeString && v.Description == "" && !v.Required && v.Default == nil
+}
+
+// variableDefinitionsFromV1 matches the variable definitions of a v1
+// ObjectTemplate to the index of the variable they define. Definitions
+// written by conversion follow the order of variables, which keeps repeated
+// names apart; definitions written by hand are matched by name.
+func variableDefinitionsFromV1(in *v1.ObjectTemplateSpec) map[int]v1.VariableDefinition {
+	matched := map[int]v1.VariableDefinition{}
+	next := 0
+	for i, name := range in.Variables {
+		if next < len(in.VariableDefinitions) && in.VariableDefinitions[next].Name == name {
+			matched[i] = in.VariableDefinitions[next]
+			next++
+		}
+	}
+	if next == len(in.VariableDefinitions) {
+		return matched
+	}
+
+	byName := map[string]v1.VariableDefinition{}
+	for _, d := range in.VariableDefinitions {
+		if _, ok := byName[d.Name]; !ok {
+			byName[d.Name] = d
+		}
+	}
+	matched = map[int]v1.VariableDefinition{}
+	for i, name := range in.Variables {
+		if d, ok := byName[name]; ok {
+			matched[i] = d
+		}
+	}
+	return matched
+}
+
+func ignoreDifferencesToV1(in []IgnoreDifference) []v1.IgnoreDifference {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1.IgnoreDifference, len(in))
+	for i, d := range in {
+		out[i] = v1.IgnoreDifference{
+			Target:                v1.PatchTarget(d.Target),
+			JSONPointers:          append([]string(nil), d.JSONPointers...),
+			ManagedFieldsManagers: append([]string(nil), d.ManagedFieldsManagers...),
+		}
+	}
+	return out
+}
+
+func ignoreDifferencesFromV1(in []v1.IgnoreDifference) []IgnoreDifference {
+	if in == nil {
+		return nil
+	}
+	out := make([]IgnoreDifference, len(in))
+	for i, d := range in {
+		out[i] = IgnoreDifference{
+			Target:                PatchTarget(d.Target),
+			JSONPointers:          append([]string(nil), d.JSONPointers...),
+			ManagedFieldsManagers: append([]string(nil), d.ManagedFieldsManagers...),
+		}
+	}
+	return out
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VariableType is the JSON type a variable value must have.
+// +kubebuilder:validation:Enum=string;integer;number;boolean;object;array
+type VariableType string
+
+const (
+	VariableTypeString  VariableType = "string"
+	VariableTypeInteger VariableType = "integer"
+	VariableTypeNumber  VariableType = "number"
+	VariableTypeBoolean VariableType = "boolean"
+	VariableTypeObject  VariableType = "object"
+	VariableTypeArray   VariableType = "array"
+)
+
+// VariableDefinition declares a variable that may be referenced by an
+// ObjectTemplate.
+type VariableDefinition struct {
+	// name of the variable as referenced from the template.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// type of the variable value.
+	// +kubebuilder:default=string
+	// +optional
+	Type VariableType `json:"type,omitempty"`
+
+	// description documents the purpose of the variable.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// required marks variables that must be set by the ApplicationTemplate
+	// or the Application.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// default is used when no value is supplied for the variable.
+	// +optional
+	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+}
+
+// ObjectTemplateSource holds the template body rendered for each Application.
+type ObjectTemplateSource struct {
+	// spec is a Go template producing the YAML spec of the rendered object.
+	// +optional
+	Spec string `json:"spec,omitempty"`
+
+	// manifests is a Go template producing a multi-document YAML stream of
+	// complete objects, rendered instead of apiVersion, kind and spec.
+	// Objects without a name are named after the Application.
+	// +optional
+	Manifests string `json:"manifests,omitempty"`
+
+	// engine renders spec or manifests. GoTemplate treats the body as a Go
+	// text/template. Substitution parses the body as YAML and replaces
+	// ${name} placeholders in its values, so substituted values never
+	// change the structure of the document.
+	// +kubebuilder:default=GoTemplate
+	// +optional
+	Engine TemplateEngine `json:"engine,omitempty"`
+}
+
+// TemplateEngine selects how an ObjectTemplate body is rendered.
+// +kubebuilder:validation:Enum=GoTemplate;Substitution
+type TemplateEngine string
+
+const (
+	// GoTemplateEngine renders the body as a Go text/template.
+	GoTemplateEngine TemplateEngine = "GoTemplate"
+
+	// SubstitutionEngine parses the body as YAML and replaces ${name}
+	// placeholders in its values.
+	SubstitutionEngine TemplateEngine = "Substitution"
+)
+
+// ObjectTemplateSpec defines the desired state of ObjectTemplate
+// +kubebuilder:validation:XValidation:rule="has(self.source.manifests) || (has(self.apiVersion) && has(self.kind))",message="apiVersion and kind are required unless source.manifests is set"
+type ObjectTemplateSpec struct {
+	// apiVersion of the rendered object. Required unless source.manifests
+	// is set.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// kind of the rendered object. Required unless source.manifests is set.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// source of the template body.
+	// +required
+	Source ObjectTemplateSource `json:"source"`
+
+	// variables declared by this template.
+	// +optional
+	Variables []VariableDefinition `json:"variables,omitempty"`
+
+	// ignoreDifferences lists fields of the rendered objects braid leaves
+	// alone once they exist.
+	// +optional
+	IgnoreDifferences []IgnoreDifference `json:"ignoreDifferences,omitempty"`
+
+	// recreateOnImmutableChange deletes and creates the rendered objects
+	// again when an update is rejected because it changes an immutable
+	// field.
+	// +optional
+	RecreateOnImmutableChange *RecreateOnImmutableChange `json:"recreateOnImmutableChange,omitempty"`
+}
+
+// RecreateOnImmutableChange configures how objects are re-created when an
+// update changes an immutable field.
+type RecreateOnImmutableChange struct {
+	// waitForDeletion deletes the object in the foreground, so its
+	// dependents, such as the Pods of a Job, are gone before it is created
+	// again.
+	// +optional
+	WaitForDeletion bool `json:"waitForDeletion,omitempty"`
+}
+
+// IgnoreDifference selects fields braid stops applying once an object exists,
+// leaving them to whoever changes them in the cluster.
+type IgnoreDifference struct {
+	// target selects the objects the fields are ignored on. Every rendered
+	// object is selected when it is empty.
+	// +optional
+	Target PatchTarget `json:"target,omitempty,omitzero"`
+
+	// jsonPointers locate ignored fields as JSON pointers (RFC 6901), such
+	// as /spec/replicas.
+	// +optional
+	JSONPointers []string `json:"jsonPointers,omitempty"`
+
+	// managedFieldsManagers ignore every field owned by these field
+	// managers, such as the manager of a HorizontalPodAutoscaler.
+	// +optional
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
+}
+
+// ObjectTemplateStatus defines the observed state of ObjectTemplate.
+type ObjectTemplateStatus struct {
+	// conditions represent the current state of the ObjectTemplate resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "Degraded": the resource failed to reach or maintain its desired state
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// referencedVariables lists the variables the template body references,
+	// whether or not they are declared in spec.variables.
+	// +optional
+	ReferencedVariables []string `json:"referencedVariables,omitempty"`
+
+	// applicationTemplates lists the ApplicationTemplates that reference this
+	// ObjectTemplate.
+	// +optional
+	ApplicationTemplates []string `json:"applicationTemplates,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=`.status.conditions[?(@.type=="Valid")].status`
+
+// ObjectTemplate is the Schema for the objecttemplates API
+type ObjectTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of ObjectTemplate
+	// +required
+	Spec ObjectTemplateSpec `json:"spec"`
+
+	// status defines the observed state of ObjectTemplate
+	// +optional
+	Status ObjectTemplateStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectTemplateList contains a list of ObjectTemplate
+type ObjectTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ObjectTemplate{}, &ObjectTemplateList{})
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+// Exit codes follow kubectl diff: 0 when nothing changes, 1 when at least one
+// object differs, and greater than 1 on error.
+const (
+	diffExitNoChanges = 0
+	diffExitChanges   = 1
+	diffExitError     = 2
+)
+
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	namespace := flags.String("namespace", "default", "Namespace for manifests that do not set one.")
+	application := flags.String("application", "", "Only diff the Application with this name.")
+	kubeconfig := flags.String("kubeconfig", "", "Path to the kubeconfig file. Defaults to the standard kubeconfig loading rules.")
+	kubeContext := flags.String("context", "", "The kubeconfig context to use.")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: braid diff [flags] <file or directory>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return diffExitError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return diffExitError
+	}
+
+	catalog, err := loadCatalog(flags.Args(), *namespace)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return diffExitError
+	}
+
+	ctx := context.Background()
+	objects, err := renderCatalog(ctx, catalog, *application)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return diffExitError
+	}
+
+	c, err := newClient(*kubeconfig, *kubeContext)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return diffExitError
+	}
+
+	changed, err := diffObjects(ctx, c, objects, stdout)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return diffExitError
+	}
+	if changed {
+		return diffExitChanges
+	}
+	return diffExitNoChanges
+}
+
+// newClient builds a client from the kubeconfig, honouring KUBECONFIG and
+// the in-cluster configuration like kubectl does.
+func newClient(kubeconfig, kubeContext string) (client.Client, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := v1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+// diffObjects writes a unified diff for every rendered object that differs
+// from the live cluster and reports whether any did.
+func diffObjects(ctx context.Context, c client.Client, objects []renderedObject, w io.Writer) (bool, error) {
+	changed := false
+	for _, o := range objects {
+		diff, err := diffObject(ctx, c, o)
+		if err != nil {
+			return false, fmt.Errorf("%s %s/%s: %w", o.GetKind(), o.GetNamespace(), o.GetName(), err)
+		}
+		if diff == "" {
+			continue
+		}
+		changed = true
+		if _, err := io.WriteString(w, diff); err != nil {
+			return false, err
+		}
+	}
+	return changed, nil
+}
+
+// diffObject compares the live object with the result of a server-side
+// dry-run apply of the rendered object, using the same field manager as the
+// controller so the result matches what the controller would do.
+func diffObject(ctx context.Context, c client.Client, o renderedObject) (string, error) {
+	desired := o.DeepCopy()
+
+	var app v1.Application
+	err := c.Get(ctx, client.ObjectKeyFromObject(o.Application), &app)
+	switch {
+	case err == nil:
+		render.SetOwner(desired, &app)
+	case !apierrors.IsNotFound(err):
+		return "", err
+	}
+
+	hashed := o.Object
+	hashed.Unstructured = desired
+	if _, err := render.SetHash(hashed); err != nil {
+		return "", err
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	err = c.Get(ctx, client.ObjectKeyFromObject(desired), live)
+	if apierrors.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return "", err
+	}
+
+	err = c.Apply(ctx, client.ApplyConfigurationFromUnstructured(desired),
+		client.FieldOwner("braid"), client.DryRunAll)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s/%s/%s", desired.GetKind(), desired.GetNamespace(), desired.GetName())
+	return unifiedDiff(name, live, desired)
+}
+
+// unifiedDiff renders a unified diff between two objects as YAML, ignoring
+// fields the API server changes on every write. A nil object is treated as
+// absent.
+func unifiedDiff(name string, from, to *unstructured.Unstructured) (string, error) {
+	a, err := diffableYAML(from)
+	if err != nil {
+		return "", err
+	}
+	b, err := diffableYAML(to)
+	if err != nil {
+		return "", err
+	}
+	if a == b {
+		return "", nil
+	}
+
+	fromFile := "live/" + name
+	if from == nil {
+		fromFile = "/dev/null"
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   "rendered/" + name,
+		Context:  3,
+	})
+}
+
+func diffableYAML(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	obj = obj.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "generation")
+
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
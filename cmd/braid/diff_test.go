@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+func renderTestdata(t *testing.T, application string) []renderedObject {
+	t.Helper()
+	g := NewWithT(t)
+
+	catalog, err := loadCatalog([]string{"testdata/render"}, "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	objects, err := renderCatalog(context.Background(), catalog, application)
+	g.Expect(err).NotTo(HaveOccurred())
+	return objects
+}
+
+// newFakeClient returns a client whose objects were previously applied by
+// braid, as the controller would have done.
+func newFakeClient(t *testing.T, objs ...*unstructured.Unstructured) client.Client {
+	t.Helper()
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(v1.AddToScheme(scheme)).To(Succeed())
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	for _, o := range objs {
+		_, err := render.SetHash(render.Object{Unstructured: o})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(c.Apply(context.Background(), client.ApplyConfigurationFromUnstructured(o), client.FieldOwner("braid"))).To(Succeed())
+	}
+	return c
+}
+
+func TestDiffReportsChangedAndNewObjects(t *testing.T) {
+	g := NewWithT(t)
+	objects := renderTestdata(t, "shop")
+
+	live := objects[0].DeepCopy()
+	g.Expect(unstructured.SetNestedField(live.Object, int64(1), "spec", "replicas")).To(Succeed())
+	c := newFakeClient(t, live)
+
+	var out bytes.Buffer
+	changed, err := diffObjects(context.Background(), c, objects, &out)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(out.String()).To(ContainSubstring("--- live/Deployment/default/shop"))
+	g.Expect(out.String()).To(ContainSubstring("-  replicas: 1\n+  replicas: 3\n"))
+	g.Expect(out.String()).To(ContainSubstring("--- /dev/null\n+++ rendered/Service/default/shop"))
+}
+
+func TestDiffReportsNoChanges(t *testing.T) {
+	g := NewWithT(t)
+	objects := renderTestdata(t, "shop")
+
+	c := newFakeClient(t, objects[0].DeepCopy(), objects[1].DeepCopy())
+
+	var out bytes.Buffer
+	changed, err := diffObjects(context.Background(), c, objects, &out)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+	g.Expect(out.String()).To(BeEmpty())
+}
+
+func TestUnifiedDiffIgnoresServerManagedFields(t *testing.T) {
+	g := NewWithT(t)
+
+	from := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "a", "resourceVersion": "1", "generation": int64(1)},
+	}}
+	to := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "a", "resourceVersion": "2", "generation": int64(2)},
+	}}
+
+	diff, err := unifiedDiff("a", from, to)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(diff).To(BeEmpty())
+}
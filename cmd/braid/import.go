@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/james226/braid/internal/importer"
+)
+
+func runImport(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "helm" {
+		_, _ = fmt.Fprintln(stderr, "Usage: braid import helm [flags] <chart directory>")
+		return 2
+	}
+	return runImportHelm(args[1:], stdout, stderr)
+}
+
+func runImportHelm(args []string, stdout, stderr io.Writer) int {
+	var values stringsFlag
+	flags := flag.NewFlagSet("import helm", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&values, "values", "Values file to render the chart with. May be repeated.")
+	name := flags.String("name", "", "Name of the ApplicationTemplate and helm release. Defaults to the chart directory name.")
+	namespace := flags.String("namespace", "default", "Namespace to render the chart for.")
+	helm := flags.String("helm", "helm", "Path to the helm binary.")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: braid import helm [flags] <chart directory>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	chart := flags.Arg(0)
+	if *name == "" {
+		abs, err := filepath.Abs(chart)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+			return 1
+		}
+		*name = filepath.Base(abs)
+	}
+
+	vals, err := importer.LoadValues(chart, values...)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return 1
+	}
+
+	renderer := importer.Helm{Binary: *helm, Chart: chart, Release: *name, Namespace: *namespace}
+	result, err := importer.Import(context.Background(), renderer, *name, vals)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return 1
+	}
+	for _, w := range result.Warnings {
+		_, _ = fmt.Fprintf(stderr, "braid: warning: %s\n", w)
+	}
+
+	objects := []any{}
+	for _, o := range result.ObjectTemplates {
+		objects = append(objects, o)
+	}
+	objects = append(objects, result.ApplicationTemplate)
+	for _, o := range objects {
+		data, err := yaml.Marshal(o)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+			return 1
+		}
+		if _, err := fmt.Fprintf(stdout, "---\n%s", data); err != nil {
+			_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+// fakeHelmEnv makes the test binary behave as a minimal `helm template`, so
+// braid import can be tested without helm installed.
+const fakeHelmEnv = "BRAID_TEST_FAKE_HELM"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeHelmEnv) == "1" {
+		if err := fakeHelm(os.Args[1:]); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeHelm implements `helm template <release> <chart> --values <file>
+// --namespace <namespace>` for charts using only Go template builtins.
+func fakeHelm(args []string) error {
+	if len(args) != 7 || args[0] != "template" || args[3] != "--values" || args[5] != "--namespace" {
+		return fmt.Errorf("unexpected arguments %q", args)
+	}
+	release, chart, valuesFile, namespace := args[1], args[2], args[4], args[6]
+
+	data, err := os.ReadFile(valuesFile)
+	if err != nil {
+		return err
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	files, err := filepath.Glob(filepath.Join(chart, "templates", "*.yaml"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		tmpl, err := template.ParseFiles(file)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("---\n# Source: %s\n", filepath.Base(file))
+		err = tmpl.Execute(os.Stdout, map[string]any{
+			"Values":  values,
+			"Release": map[string]any{"Name": release, "Namespace": namespace},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestImportHelm(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(fakeHelmEnv, "1")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"import", "helm", "--helm", os.Args[0],
+		"--values", "testdata/import/values.yaml", "testdata/import/chart"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(0), stderr.String())
+	g.Expect(stderr.String()).To(BeEmpty())
+
+	golden(t, "import.golden.yaml", stdout.Bytes())
+}
+
+func TestImportHelmRendersChart(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(fakeHelmEnv, "1")
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"import", "helm", "--helm", os.Args[0], "--name", "web", "testdata/import/chart"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(0), stderr.String())
+
+	application := "apiVersion: braid.james-parker.dev/v1\nkind: Application\nmetadata:\n  name: web\nspec:\n  template: web\n"
+	g.Expect(os.WriteFile(filepath.Join(dir, "templates.yaml"), stdout.Bytes(), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "application.yaml"), []byte(application), 0o644)).To(Succeed())
+
+	stdout.Reset()
+	code = run([]string{"render", dir}, &stdout, &stderr)
+	g.Expect(code).To(Equal(0), stderr.String())
+	g.Expect(stdout.String()).To(ContainSubstring("image: nginx:1.27\n"))
+	g.Expect(stdout.String()).To(ContainSubstring("replicas: 1\n"))
+	g.Expect(stdout.String()).To(ContainSubstring("port: 80\n"))
+}
+
+func TestImportRequiresHelmSubcommand(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	g.Expect(run([]string{"import", "kustomize"}, &stdout, &stderr)).To(Equal(2))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/james226/braid/internal/lint"
+)
+
+// Exit codes for braid lint.
+const (
+	lintExitClean    = 0
+	lintExitFindings = 1
+	lintExitError    = 2
+)
+
+// stringsFlag is a flag that may be repeated.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string { return strings.Join(*s, ",") }
+
+func (s *stringsFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func runLint(args []string, stdout, stderr io.Writer) int {
+	var openapi stringsFlag
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	namespace := flags.String("namespace", "default", "Namespace for manifests that do not set one.")
+	format := flags.String("format", "text", "Output format: text, json or sarif.")
+	flags.Var(&openapi, "openapi", "OpenAPI v2 or v3 JSON document listing the API versions templates may use, "+
+		"for example the output of `kubectl get --raw /openapi/v2`. May be repeated. "+
+		"Replaces the built-in Kubernetes API list.")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: braid lint [flags] <file or directory>...")
+		_, _ = fmt.Fprintln(stderr, "\nExits 1 when an error is found.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return lintExitError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return lintExitError
+	}
+
+	var write func(io.Writer, []lint.Finding) error
+	switch *format {
+	case "text":
+		write = lint.WriteText
+	case "json":
+		write = lint.WriteJSON
+	case "sarif":
+		write = lint.WriteSARIF
+	default:
+		_, _ = fmt.Fprintf(stderr, "braid: unknown format %q\n", *format)
+		return lintExitError
+	}
+
+	schema, err := loadSchema(openapi)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return lintExitError
+	}
+
+	catalog, sources, err := loadSources(flags.Args(), *namespace)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return lintExitError
+	}
+
+	findings := lint.Lint(context.Background(), catalog, lint.Options{
+		Schema: schema,
+		Source: func(obj client.Object) string { return sources[obj] },
+	})
+	if err := write(stdout, findings); err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return lintExitError
+	}
+
+	if lint.HasErrors(findings) {
+		return lintExitFindings
+	}
+	return lintExitClean
+}
+
+// loadSchema reads the given OpenAPI documents, falling back to the built-in
+// schema when there are none.
+func loadSchema(paths []string) (*lint.Schema, error) {
+	if len(paths) == 0 {
+		return lint.BuiltinSchema(), nil
+	}
+
+	schema := lint.NewSchema()
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		err = schema.LoadOpenAPI(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return schema, nil
+}
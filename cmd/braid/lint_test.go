@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLintReportsFindings(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"lint", "testdata/lint/broken"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(lintExitFindings), stderr.String())
+
+	golden(t, "lint.golden.txt", stdout.Bytes())
+}
+
+func TestLintSARIF(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"lint", "--format", "sarif", "testdata/lint/broken"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(lintExitFindings), stderr.String())
+
+	golden(t, "lint.golden.sarif", stdout.Bytes())
+}
+
+func TestLintClean(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"lint", "--format", "json", "testdata/lint/clean"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(lintExitClean), stderr.String())
+	g.Expect(stdout.String()).To(Equal("[]\n"))
+}
+
+func TestLintSuppliedSchema(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"lint", "--openapi", "testdata/lint/openapi.json", "testdata/lint/clean"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(lintExitClean), stderr.String())
+
+	stdout.Reset()
+	code = run([]string{"lint", "--openapi", "testdata/lint/openapi.json", "testdata/lint/broken"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(lintExitFindings), stderr.String())
+	g.Expect(stdout.String()).To(ContainSubstring(`unknown apiVersion "apps/v1"`))
+}
+
+func TestLintUnknownFormat(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"lint", "--format", "xml", "testdata/lint/clean"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(lintExitError))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/james226/braid/internal/render"
+)
+
+// loadCatalog reads every YAML file in paths, descending into directories.
+func loadCatalog(paths []string, namespace string) (*render.Catalog, error) {
+	catalog, _, err := loadSources(paths, namespace)
+	return catalog, err
+}
+
+// loadSources is loadCatalog, also returning the file each object was read
+// from.
+func loadSources(paths []string, namespace string) (*render.Catalog, map[client.Object]string, error) {
+	catalog := &render.Catalog{}
+	sources := map[client.Object]string{}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !isYAML(file) {
+				return nil
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = f.Close() }()
+
+			apps, appTemplates, objTemplates := len(catalog.Applications),
+				len(catalog.ApplicationTemplates), len(catalog.ObjectTemplates)
+			if err := catalog.Load(f, namespace); err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			for _, o := range catalog.Applications[apps:] {
+				sources[o] = file
+			}
+			for _, o := range catalog.ApplicationTemplates[appTemplates:] {
+				sources[o] = file
+			}
+			for _, o := range catalog.ObjectTemplates[objTemplates:] {
+				sources[o] = file
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return catalog, sources, nil
+}
+
+func isYAML(file string) bool {
+	ext := strings.ToLower(filepath.Ext(file))
+	return ext == ".yaml" || ext == ".yml"
+}
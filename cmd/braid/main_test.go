@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// golden compares got with the named file in testdata, rewriting it when
+// the tests are run with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	g := NewWithT(t)
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		g.Expect(os.WriteFile(path, got, 0o644)).To(Succeed())
+	}
+	want, err := os.ReadFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(got)).To(Equal(string(want)))
+}
+
+func TestRenderToStdout(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"render", "testdata/render"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(0), stderr.String())
+
+	golden(t, "render.golden.yaml", stdout.Bytes())
+}
+
+func TestRenderSingleApplication(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"render", "--application", "blog", "testdata/render"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(0), stderr.String())
+	g.Expect(stdout.String()).To(ContainSubstring("Application: default/blog"))
+	g.Expect(stdout.String()).NotTo(ContainSubstring("Application: default/shop"))
+}
+
+func TestRenderToDirectory(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"render", "--application", "shop", "--output-dir", dir, "testdata/render"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(0), stderr.String())
+	g.Expect(stdout.String()).To(BeEmpty())
+
+	entries, err := os.ReadDir(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	g.Expect(names).To(ConsistOf("default_deployment.apps_shop.yaml", "default_service_shop.yaml"))
+}
+
+func TestRenderMissingTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"render", "testdata/render/applications.yaml"}, &stdout, &stderr)
+	g.Expect(code).To(Equal(1))
+	g.Expect(stderr.String()).To(ContainSubstring(`"default/web" not found`))
+}
+
+func TestFileNameIncludesGroup(t *testing.T) {
+	g := NewWithT(t)
+
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion("monitoring.coreos.com/v1")
+	object.SetKind("ServiceMonitor")
+	object.SetNamespace("default")
+	object.SetName("shop")
+	g.Expect(fileName(object)).To(Equal("default_servicemonitor.monitoring.coreos.com_shop.yaml"))
+
+	object.SetAPIVersion("v1")
+	object.SetKind("Service")
+	g.Expect(fileName(object)).To(Equal("default_service_shop.yaml"))
+}
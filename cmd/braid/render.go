@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+func runRender(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	namespace := flags.String("namespace", "default", "Namespace for manifests that do not set one.")
+	application := flags.String("application", "", "Only render the Application with this name.")
+	outputDir := flags.String("output-dir", "", "Write one file per rendered object to this directory instead of stdout.")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: braid render [flags] <file or directory>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	catalog, err := loadCatalog(flags.Args(), *namespace)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return 1
+	}
+
+	objects, err := renderCatalog(context.Background(), catalog, *application)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return 1
+	}
+
+	if *outputDir != "" {
+		err = writeObjects(*outputDir, objects)
+	} else {
+		err = printObjects(stdout, objects)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "braid: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// renderedObject is an object rendered for a specific Application.
+type renderedObject struct {
+	Application *v1.Application
+	render.Object
+}
+
+// renderCatalog renders every Application in the catalog, or only the one
+// named application when it is set.
+func renderCatalog(ctx context.Context, catalog *render.Catalog, application string) ([]renderedObject, error) {
+	var rendered []renderedObject
+	found := false
+	for _, app := range catalog.Applications {
+		if application != "" && app.Name != application {
+			continue
+		}
+		found = true
+
+		objects, err := render.Application(ctx, catalog, app)
+		if err != nil {
+			return nil, fmt.Errorf("application %s/%s: %w", app.Namespace, app.Name, err)
+		}
+		for _, o := range objects {
+			rendered = append(rendered, renderedObject{Application: app, Object: o})
+		}
+	}
+	if application != "" && !found {
+		return nil, fmt.Errorf("application %q not found", application)
+	}
+	return rendered, nil
+}
+
+func printObjects(w io.Writer, objects []renderedObject) error {
+	for _, o := range objects {
+		out, err := yaml.Marshal(o.Object.Object)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "---\n# Application: %s/%s, ObjectTemplate: %s\n%s",
+			o.Application.Namespace, o.Application.Name, o.Template, out)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeObjects(dir string, objects []renderedObject) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	written := map[string]string{}
+	for _, o := range objects {
+		out, err := yaml.Marshal(o.Object.Object)
+		if err != nil {
+			return err
+		}
+		name := fileName(o.Unstructured)
+		if other, ok := written[name]; ok {
+			return fmt.Errorf("%s and %s would both be written to %s", other, render.Key(o.Unstructured), name)
+		}
+		written[name] = render.Key(o.Unstructured)
+		if err := os.WriteFile(filepath.Join(dir, name), out, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileName names the file an object is written to in the output directory.
+// Kinds outside the core group are qualified with their group, as in
+// default_deployment.apps_web.yaml.
+func fileName(object *unstructured.Unstructured) string {
+	kind := object.GetKind()
+	if group := object.GroupVersionKind().Group; group != "" {
+		kind += "." + group
+	}
+	return strings.ToLower(fmt.Sprintf("%s_%s_%s.yaml", object.GetNamespace(), kind, object.GetName()))
+}
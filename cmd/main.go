@@ -17,9 +17,11 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -36,7 +38,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	braidjamesparkerdevv1 "github.com/james226/braid/api/v1"
+	braidjamesparkerdevv2 "github.com/james226/braid/api/v2"
 	"github.com/james226/braid/internal/controller"
+	"github.com/james226/braid/internal/tracing"
+	webhookv1 "github.com/james226/braid/internal/webhook/v1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -49,6 +54,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(braidjamesparkerdevv1.AddToScheme(scheme))
+	utilruntime.Must(braidjamesparkerdevv2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -61,6 +67,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var resyncInterval time.Duration
+	var backoffBase, backoffMax time.Duration
+	var tracingOpts tracing.Options
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -79,6 +88,19 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&resyncInterval, "resync-interval", 10*time.Minute,
+		"How often objects whose rendered manifest has not changed are applied anyway, to correct drift.")
+	flag.DurationVar(&backoffBase, "backoff-base", time.Second,
+		"The delay before an Application is retried after a transient error, doubled with every further error.")
+	flag.DurationVar(&backoffMax, "backoff-max", 5*time.Minute,
+		"The longest delay before an Application is retried after transient errors.")
+	flag.StringVar(&tracingOpts.Endpoint, "otlp-endpoint", "",
+		"The OTLP gRPC endpoint reconcile traces are exported to, such as otel-collector:4317. "+
+			"Traces are not exported when it is empty.")
+	flag.BoolVar(&tracingOpts.Insecure, "otlp-insecure", false,
+		"If set, traces are exported to the OTLP endpoint without TLS.")
+	flag.Float64Var(&tracingOpts.SampleRatio, "trace-sample-ratio", 1,
+		"The fraction of reconciles that are traced.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -87,6 +109,13 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	ctx := ctrl.SetupSignalHandler()
+	shutdownTracing, err := tracing.Setup(ctx, tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -179,8 +208,12 @@ func main() {
 	}
 
 	if err := (&controller.ApplicationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorderFor("braid"),
+		ResyncInterval: resyncInterval,
+		BackoffBase:    backoffBase,
+		BackoffMax:     backoffMax,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Application")
 		os.Exit(1)
@@ -193,6 +226,35 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ApplicationTemplate")
 		os.Exit(1)
 	}
+	if err := (&controller.ObjectTemplateReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ObjectTemplate")
+		os.Exit(1)
+	}
+	if err := (&controller.TemplateSourceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TemplateSource")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1.SetupApplicationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Application")
+			os.Exit(1)
+		}
+		if err := webhookv1.SetupApplicationTemplateWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ApplicationTemplate")
+			os.Exit(1)
+		}
+		if err := webhookv1.SetupObjectTemplateWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ObjectTemplate")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -205,8 +267,11 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
 }
@@ -0,0 +1,212 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Conflict is a field of an applied object owned by another field manager.
+type Conflict struct {
+	// Field is the path of the field as reported by the API server, e.g.
+	// .spec.template.spec.containers[name="web"].image.
+	Field string
+	// Manager is the name of the field manager that owns the field.
+	Manager string
+}
+
+// Conflicts returns the field conflicts reported by a server-side apply
+// error, or nil when err is not an apply conflict.
+func Conflicts(err error) []Conflict {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || !apierrors.IsConflict(err) {
+		return nil
+	}
+	details := status.Status().Details
+	if details == nil {
+		return nil
+	}
+
+	var conflicts []Conflict
+	for _, cause := range details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{Field: cause.Field, Manager: manager(cause.Message)})
+	}
+	return conflicts
+}
+
+// manager extracts the manager name from a conflict message such as
+// `conflict with "kubectl" using apps/v1`.
+func manager(message string) string {
+	rest, ok := strings.CutPrefix(message, "conflict with ")
+	if !ok {
+		return message
+	}
+	quoted, err := strconv.QuotedPrefix(rest)
+	if err != nil {
+		return rest
+	}
+	name, err := strconv.Unquote(quoted)
+	if err != nil {
+		return rest
+	}
+	return name
+}
+
+// RemoveField deletes the field at path, in the format used by Conflict,
+// from object. Paths naming a list item remove the whole item. It reports
+// whether the field was found.
+func RemoveField(object map[string]interface{}, path string) bool {
+	_, ok := remove(object, path)
+	return ok
+}
+
+// remove deletes the field at path below node and returns the updated node,
+// which differs from node when a list item was removed.
+func remove(node interface{}, path string) (interface{}, bool) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		rest, ok := strings.CutPrefix(path, ".")
+		if !ok {
+			return node, false
+		}
+		// Field names may contain dots, as annotation keys do, so match
+		// against the keys present rather than splitting the path.
+		for key, child := range n {
+			after, ok := strings.CutPrefix(rest, key)
+			if !ok {
+				continue
+			}
+			if after == "" {
+				delete(n, key)
+				return n, true
+			}
+			if after[0] != '.' && after[0] != '[' {
+				continue
+			}
+			if updated, ok := remove(child, after); ok {
+				n[key] = updated
+				return n, true
+			}
+		}
+	case []interface{}:
+		selector, after, ok := element(path)
+		if !ok {
+			return node, false
+		}
+		for i, item := range n {
+			if !matches(item, i, selector) {
+				continue
+			}
+			if after == "" {
+				return append(n[:i:i], n[i+1:]...), true
+			}
+			if updated, ok := remove(item, after); ok {
+				n[i] = updated
+				return n, true
+			}
+		}
+	}
+	return node, false
+}
+
+// element splits the leading [...] selector from path, honouring quoted
+// strings that contain brackets.
+func element(path string) (selector, rest string, ok bool) {
+	if !strings.HasPrefix(path, "[") {
+		return "", "", false
+	}
+	quoted := false
+	for i := 1; i < len(path); i++ {
+		switch {
+		case quoted && path[i] == '\\':
+			i++
+		case path[i] == '"':
+			quoted = !quoted
+		case !quoted && path[i] == ']':
+			return path[1:i], path[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// matches reports whether the list item at index is selected by selector,
+// which is an index, a set value (=value) or list map keys (k1=v1,k2=v2).
+func matches(item interface{}, index int, selector string) bool {
+	if value, ok := strings.CutPrefix(selector, "="); ok {
+		return format(item) == value
+	}
+	if i, err := strconv.Atoi(selector); err == nil {
+		return i == index
+	}
+
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, key := range split(selector) {
+		name, value, ok := strings.Cut(key, "=")
+		if !ok {
+			return false
+		}
+		field, ok := fields[name]
+		if !ok || format(field) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// split separates list map keys on the commas outside quoted strings.
+func split(selector string) []string {
+	var keys []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(selector); i++ {
+		switch {
+		case quoted && selector[i] == '\\':
+			i++
+		case selector[i] == '"':
+			quoted = !quoted
+		case !quoted && selector[i] == ',':
+			keys = append(keys, selector[start:i])
+			start = i + 1
+		}
+	}
+	return append(keys, selector[start:])
+}
+
+// format prints a scalar the way the API server prints values in field
+// paths.
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
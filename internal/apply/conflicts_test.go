@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+func TestConflicts(t *testing.T) {
+	g := NewWithT(t)
+
+	err := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl-client-side-apply" using apps/v1`, Field: ".spec.replicas"},
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "hpa"`, Field: `.spec.template.spec.containers[name="web"].image`},
+		{Type: metav1.CauseTypeFieldValueInvalid, Message: "ignored", Field: ".spec"},
+	}, "Apply failed with 2 conflicts")
+
+	g.Expect(Conflicts(err)).To(Equal([]Conflict{
+		{Field: ".spec.replicas", Manager: "kubectl-client-side-apply"},
+		{Field: `.spec.template.spec.containers[name="web"].image`, Manager: "hpa"},
+	}))
+	g.Expect(Conflicts(errors.New("boom"))).To(BeNil())
+	g.Expect(Conflicts(apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, "web", errors.New("stale")))).To(BeNil())
+}
+
+func TestRemoveField(t *testing.T) {
+	object := func() map[string]interface{} {
+		var o map[string]interface{}
+		if err := yaml.Unmarshal([]byte(`
+metadata:
+  annotations:
+    example.com/owner: team
+    note: x
+  finalizers: [a, b]
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx
+          ports:
+            - containerPort: 80
+              protocol: TCP
+        - name: "sidecar [1]"
+          image: envoy
+`), &o); err != nil {
+			t.Fatal(err)
+		}
+		return o
+	}
+
+	containers := func(o map[string]interface{}) []interface{} {
+		return o["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+	}
+	metadata := func(o map[string]interface{}) map[string]interface{} {
+		return o["metadata"].(map[string]interface{})
+	}
+
+	tests := []struct {
+		path  string
+		check func(g Gomega, o map[string]interface{})
+	}{
+		{".spec.replicas", func(g Gomega, o map[string]interface{}) {
+			g.Expect(o["spec"]).NotTo(HaveKey("replicas"))
+		}},
+		{".metadata.annotations.example.com/owner", func(g Gomega, o map[string]interface{}) {
+			g.Expect(metadata(o)["annotations"]).To(Equal(map[string]interface{}{"note": "x"}))
+		}},
+		{`.spec.template.spec.containers[name="web"].image`, func(g Gomega, o map[string]interface{}) {
+			g.Expect(containers(o)[0]).NotTo(HaveKey("image"))
+			g.Expect(containers(o)[1]).To(HaveKeyWithValue("image", "envoy"))
+		}},
+		{`.spec.template.spec.containers[name="sidecar [1]"]`, func(g Gomega, o map[string]interface{}) {
+			g.Expect(containers(o)).To(HaveLen(1))
+		}},
+		{`.spec.template.spec.containers[name="web"].ports[containerPort=80,protocol="TCP"]`, func(g Gomega, o map[string]interface{}) {
+			g.Expect(containers(o)[0]).To(HaveKeyWithValue("ports", BeEmpty()))
+		}},
+		{`.metadata.finalizers[="a"]`, func(g Gomega, o map[string]interface{}) {
+			g.Expect(metadata(o)["finalizers"]).To(Equal([]interface{}{"b"}))
+		}},
+		{".metadata.finalizers[1]", func(g Gomega, o map[string]interface{}) {
+			g.Expect(metadata(o)["finalizers"]).To(Equal([]interface{}{"a"}))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			g := NewWithT(t)
+			o := object()
+			g.Expect(RemoveField(o, tt.path)).To(BeTrue())
+			tt.check(g, o)
+		})
+	}
+
+	g := NewWithT(t)
+	g.Expect(RemoveField(object(), ".spec.paused")).To(BeFalse())
+	g.Expect(RemoveField(object(), `.spec.template.spec.containers[name="db"]`)).To(BeFalse())
+}
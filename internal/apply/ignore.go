@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// ManagedFields returns the paths, in the format used by Conflict, of the
+// fields of object owned by any of managers. Fields owned through the status
+// subresource are left out, as braid never applies status.
+func ManagedFields(object *unstructured.Unstructured, managers []string) ([]string, error) {
+	var paths []string
+	for _, entry := range object.GetManagedFields() {
+		if !slices.Contains(managers, entry.Manager) || entry.Subresource == "status" || entry.FieldsV1 == nil {
+			continue
+		}
+		set := fieldpath.NewSet()
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, fmt.Errorf("reading fields managed by %q: %w", entry.Manager, err)
+		}
+		set.Leaves().Iterate(func(p fieldpath.Path) {
+			paths = append(paths, p.String())
+		})
+	}
+	return paths, nil
+}
+
+// PointerField converts a JSON pointer (RFC 6901) to a path in the format used
+// by Conflict. Tokens are read as list indexes where any of objects holds a
+// list at that position.
+func PointerField(pointer string, objects ...map[string]interface{}) (string, error) {
+	if pointer == "" {
+		return "", fmt.Errorf("JSON pointer %q selects the whole object", pointer)
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return "", fmt.Errorf("JSON pointer %q must start with /", pointer)
+	}
+
+	nodes := make([]interface{}, len(objects))
+	for i, o := range objects {
+		nodes[i] = o
+	}
+	var path strings.Builder
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		index, err := strconv.Atoi(token)
+		list := false
+		for _, n := range nodes {
+			if _, ok := n.([]interface{}); ok {
+				list = true
+			}
+		}
+		if list && err == nil {
+			fmt.Fprintf(&path, "[%d]", index)
+		} else {
+			path.WriteString("." + token)
+		}
+
+		for i, n := range nodes {
+			switch n := n.(type) {
+			case map[string]interface{}:
+				nodes[i] = n[token]
+			case []interface{}:
+				if err == nil && index >= 0 && index < len(n) {
+					nodes[i] = n[index]
+				} else {
+					nodes[i] = nil
+				}
+			default:
+				nodes[i] = nil
+			}
+		}
+	}
+	return path.String(), nil
+}
+
+// CopyField sets the field at path, in the format used by Conflict, in object
+// to its value in from, or removes it from object when from does not have
+// it. Missing list items are appended whole.
+func CopyField(object, from map[string]interface{}, path string) {
+	if _, ok := copyField(object, from, path); !ok {
+		RemoveField(object, path)
+	}
+}
+
+// copyField copies the field at path below src into dst and returns the
+// updated dst, which is created when it is missing.
+func copyField(dst, src interface{}, path string) (interface{}, bool) {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		rest, ok := strings.CutPrefix(path, ".")
+		if !ok {
+			return dst, false
+		}
+		d, _ := dst.(map[string]interface{})
+		// As in remove, match against the keys of src since field names may
+		// contain dots.
+		for key, child := range s {
+			after, ok := strings.CutPrefix(rest, key)
+			if !ok || (after != "" && after[0] != '.' && after[0] != '[') {
+				continue
+			}
+			if d == nil {
+				d = map[string]interface{}{}
+			}
+			if after == "" {
+				d[key] = runtime.DeepCopyJSONValue(child)
+				return d, true
+			}
+			if updated, ok := copyField(d[key], child, after); ok {
+				d[key] = updated
+				return d, true
+			}
+		}
+	case []interface{}:
+		selector, after, ok := element(path)
+		if !ok {
+			return dst, false
+		}
+		d, _ := dst.([]interface{})
+		for i, item := range s {
+			if !matches(item, i, selector) {
+				continue
+			}
+			for j, existing := range d {
+				if !matches(existing, j, selector) {
+					continue
+				}
+				if after == "" {
+					d[j] = runtime.DeepCopyJSONValue(item)
+					return d, true
+				}
+				updated, ok := copyField(existing, item, after)
+				if ok {
+					d[j] = updated
+				}
+				return d, ok
+			}
+			return append(d, runtime.DeepCopyJSONValue(item)), true
+		}
+	}
+	return dst, false
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func object(t *testing.T, manifest string) map[string]interface{} {
+	var o map[string]interface{}
+	if err := yaml.Unmarshal([]byte(manifest), &o); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestManagedFields(t *testing.T) {
+	g := NewWithT(t)
+
+	live := &unstructured.Unstructured{}
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: "braid", Operation: metav1.ManagedFieldsOperationApply, FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{},"f:selector":{}}}`)}},
+		{Manager: "hpa", Operation: metav1.ManagedFieldsOperationUpdate, FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+		{Manager: "hpa", Operation: metav1.ManagedFieldsOperationUpdate, FieldsType: "FieldsV1", Subresource: "status",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:replicas":{}}}`)}},
+		{Manager: "injector", Operation: metav1.ManagedFieldsOperationUpdate, FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:example.com/injected":{}}},"f:spec":{"f:containers":{"k:{\"name\":\"proxy\"}":{".":{},"f:image":{},"f:name":{}}}}}`)}},
+	})
+
+	g.Expect(ManagedFields(live, []string{"hpa"})).To(Equal([]string{".spec.replicas"}))
+	g.Expect(ManagedFields(live, []string{"injector"})).To(ConsistOf(
+		".metadata.annotations.example.com/injected",
+		`.spec.containers[name="proxy"].image`,
+		`.spec.containers[name="proxy"].name`,
+	))
+	g.Expect(ManagedFields(live, []string{"kubectl"})).To(BeEmpty())
+
+	live.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: "hpa", FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"spec":{}}`)}},
+	})
+	_, err := ManagedFields(live, []string{"hpa"})
+	g.Expect(err).To(MatchError(ContainSubstring(`reading fields managed by "hpa"`)))
+}
+
+func TestPointerField(t *testing.T) {
+	desired := object(t, `
+metadata:
+  annotations:
+    example.com/owner: team
+data:
+  "0": zero
+spec:
+  containers:
+    - name: web
+      image: nginx
+`)
+	live := object(t, `
+spec:
+  tolerations:
+    - key: a
+`)
+
+	for pointer, path := range map[string]string{
+		"/spec/replicas":                           ".spec.replicas",
+		"/spec/containers/0/image":                 ".spec.containers[0].image",
+		"/spec/tolerations/0":                      ".spec.tolerations[0]",
+		"/metadata/annotations/example.com~1owner": ".metadata.annotations.example.com/owner",
+		"/data/0":    ".data.0",
+		"/data/a~0b": ".data.a~b",
+	} {
+		g := NewWithT(t)
+		g.Expect(PointerField(pointer, desired, live)).To(Equal(path), pointer)
+	}
+
+	g := NewWithT(t)
+	_, err := PointerField("spec/replicas", desired)
+	g.Expect(err).To(MatchError(`JSON pointer "spec/replicas" must start with /`))
+	_, err = PointerField("", desired)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCopyField(t *testing.T) {
+	live := object(t, `
+metadata:
+  annotations:
+    example.com/injected: "true"
+spec:
+  replicas: 5
+  containers:
+    - name: web
+      image: nginx:1.27
+    - name: proxy
+      image: envoy
+`)
+
+	g := NewWithT(t)
+	desired := object(t, `
+metadata:
+  labels:
+    app: web
+spec:
+  replicas: 2
+  paused: true
+  containers:
+    - name: web
+      image: nginx:1.26
+`)
+	CopyField(desired, live, ".spec.replicas")
+	CopyField(desired, live, `.spec.containers[name="web"].image`)
+	CopyField(desired, live, `.spec.containers[name="proxy"]`)
+	CopyField(desired, live, ".metadata.annotations.example.com/injected")
+	CopyField(desired, live, ".spec.paused")
+	g.Expect(desired).To(Equal(object(t, `
+metadata:
+  labels:
+    app: web
+  annotations:
+    example.com/injected: "true"
+spec:
+  replicas: 5
+  containers:
+    - name: web
+      image: nginx:1.27
+    - name: proxy
+      image: envoy
+`)))
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"errors"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Immutable reports whether err is the API server rejecting a write because
+// it changes a field that cannot be updated, such as the template of a Job,
+// the selector of a Deployment or the volumeClaimTemplates of a StatefulSet.
+func Immutable(err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || !apierrors.IsInvalid(err) {
+		return false
+	}
+	messages := []string{status.Status().Message}
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			messages = append(messages, cause.Message)
+		}
+	}
+	for _, m := range messages {
+		if strings.Contains(m, "field is immutable") ||
+			strings.Contains(m, "Forbidden: updates to") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestImmutable(t *testing.T) {
+	g := NewWithT(t)
+
+	invalid := func(errs ...*field.Error) error {
+		return apierrors.NewInvalid(schema.GroupKind{Group: "batch", Kind: "Job"}, "migrate", errs)
+	}
+
+	g.Expect(Immutable(invalid(field.Invalid(field.NewPath("spec", "template"), "x", "field is immutable")))).To(BeTrue())
+	g.Expect(Immutable(invalid(field.Forbidden(field.NewPath("data"), "field is immutable when `immutable` is set")))).To(BeTrue())
+	g.Expect(Immutable(invalid(field.Forbidden(field.NewPath("spec"),
+		"updates to statefulset spec for fields other than 'replicas' are forbidden")))).To(BeTrue())
+
+	g.Expect(Immutable(invalid(field.Required(field.NewPath("spec", "template"), "")))).To(BeFalse())
+	g.Expect(Immutable(apierrors.NewForbidden(schema.GroupResource{Resource: "jobs"}, "migrate", errors.New("field is immutable")))).To(BeFalse())
+	g.Expect(Immutable(errors.New("field is immutable"))).To(BeFalse())
+}
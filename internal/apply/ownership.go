@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"bytes"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// TransferFields takes the fields manager owns away from every other field
+// manager, leaving manager their only owner. Entries of subresources are
+// kept, and entries left without fields are dropped. It reports whether
+// anything changed.
+func TransferFields(entries []metav1.ManagedFieldsEntry, manager string) ([]metav1.ManagedFieldsEntry, bool, error) {
+	sets := make([]*fieldpath.Set, len(entries))
+	owned := fieldpath.NewSet()
+	for i, entry := range entries {
+		if entry.FieldsV1 == nil || entry.Subresource != "" {
+			continue
+		}
+		sets[i] = fieldpath.NewSet()
+		if err := sets[i].FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, false, fmt.Errorf("reading fields managed by %q: %w", entry.Manager, err)
+		}
+		if entry.Manager == manager {
+			owned = owned.Union(sets[i])
+		}
+	}
+
+	var transferred []metav1.ManagedFieldsEntry
+	changed := false
+	for i, entry := range entries {
+		if sets[i] == nil || entry.Manager == manager {
+			transferred = append(transferred, entry)
+			continue
+		}
+		rest := sets[i].Difference(owned)
+		if rest.Equals(sets[i]) {
+			transferred = append(transferred, entry)
+			continue
+		}
+		changed = true
+		if rest.Empty() {
+			continue
+		}
+		raw, err := rest.ToJSON()
+		if err != nil {
+			return nil, false, err
+		}
+		entry.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+		transferred = append(transferred, entry)
+	}
+	return transferred, changed, nil
+}
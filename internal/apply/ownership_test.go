@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransferFields(t *testing.T) {
+	g := NewWithT(t)
+
+	entry := func(manager, subresource, fields string) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{
+			Manager:     manager,
+			Operation:   metav1.ManagedFieldsOperationUpdate,
+			FieldsType:  "FieldsV1",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(fields)},
+			Subresource: subresource,
+		}
+	}
+
+	entries := []metav1.ManagedFieldsEntry{
+		entry("braid", "", `{"f:data":{"f:key":{}}}`),
+		entry("kubectl", "", `{"f:data":{"f:extra":{},"f:key":{}}}`),
+		entry("editor", "", `{"f:data":{"f:key":{}}}`),
+		entry("kubectl", "status", `{"f:data":{"f:key":{}}}`),
+	}
+	transferred, changed, err := TransferFields(entries, "braid")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(transferred).To(Equal([]metav1.ManagedFieldsEntry{
+		entries[0],
+		entry("kubectl", "", `{"f:data":{"f:extra":{}}}`),
+		entries[3],
+	}))
+
+	_, changed, err = TransferFields(transferred, "braid")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+}
@@ -17,34 +17,97 @@ limitations under the License.
 package controller
 
 import (
-    "bytes"
     "context"
-    "text/template"
-
+    "encoding/json"
+    stderrors "errors"
+    "fmt"
+    "slices"
+    "time"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/trace"
+    corev1 "k8s.io/api/core/v1"
+    "k8s.io/apimachinery/pkg/api/equality"
     "k8s.io/apimachinery/pkg/api/errors"
+    "k8s.io/apimachinery/pkg/api/meta"
     metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
     "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
     "k8s.io/apimachinery/pkg/runtime"
-    "k8s.io/apimachinery/pkg/runtime/schema"
     "k8s.io/apimachinery/pkg/types"
-    "k8s.io/apimachinery/pkg/util/yaml"
+    "k8s.io/client-go/tools/record"
+    "k8s.io/client-go/util/workqueue"
     "k8s.io/utils/ptr"
     ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/builder"
     "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/controller"
+    "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+    "sigs.k8s.io/controller-runtime/pkg/event"
+    "sigs.k8s.io/controller-runtime/pkg/handler"
     logf "sigs.k8s.io/controller-runtime/pkg/log"
+    "sigs.k8s.io/controller-runtime/pkg/predicate"
+    "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
     v1 "github.com/james226/braid/api/v1"
+    "github.com/james226/braid/internal/apply"
+    "github.com/james226/braid/internal/metrics"
+    "github.com/james226/braid/internal/render"
+    "github.com/james226/braid/internal/tracing"
 )
 
+// ApplicationFinalizer holds a deleted Application until its objects have been
+// released according to their deletion policy.
+const ApplicationFinalizer = "braid.james-parker.dev/finalizer"
+
+// retainedRequeue is how often a deleted Application checks whether its
+// retained objects may be deleted.
+const retainedRequeue = time.Minute
+
+// recreateRequeue is how often an Application checks whether an object it
+// deleted to re-create is gone.
+const recreateRequeue = 5 * time.Second
+
+// defaultBackoffBase and defaultBackoffMax bound the exponential backoff
+// with which Applications are retried after transient errors, unless set
+// otherwise.
+const (
+    defaultBackoffBase = time.Second
+    defaultBackoffMax  = 5 * time.Minute
+)
+
+// defaultResyncInterval is how often every object of an Application is
+// applied, whether or not its manifest changed, unless set otherwise.
+const defaultResyncInterval = 10 * time.Minute
+
 // ApplicationReconciler reconciles a Application object
 type ApplicationReconciler struct {
     client.Client
-    Scheme *runtime.Scheme
+    Scheme   *runtime.Scheme
+    Recorder record.EventRecorder
+
+    // ResyncInterval is how often objects whose manifest has not changed are
+    // applied anyway, to correct drift. It is defaultResyncInterval when
+    // zero.
+    ResyncInterval time.Duration
+
+    // BackoffBase is the delay before an Application is retried after its
+    // first transient error, doubling with every further error up to
+    // BackoffMax. They are defaultBackoffBase and defaultBackoffMax when
+    // zero.
+    BackoffBase time.Duration
+    BackoffMax  time.Duration
+
+    // TracerProvider records a span for every reconcile. It is the global
+    // tracer provider when nil.
+    TracerProvider trace.TracerProvider
 }
 
 // +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applications/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applications/finalizers,verbs=update
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=objecttemplates;applicationtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -52,6 +115,20 @@ type ApplicationReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
 func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+    provider := r.TracerProvider
+    if provider == nil {
+        provider = otel.GetTracerProvider()
+    }
+    ctx, span := provider.Tracer(tracing.Name).Start(ctx, "Reconcile", trace.WithAttributes(
+        tracing.ApplicationNamespace.String(req.Namespace),
+        tracing.ApplicationName.String(req.Name),
+    ))
+    result, err := r.reconcile(ctx, req)
+    tracing.End(span, err)
+    return result, err
+}
+
+func (r *ApplicationReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
     l := logf.FromContext(ctx)
 
     l.Info("Reconcile request", "namespace", req.Namespace, "name", req.Name)
@@ -64,16 +141,35 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
         return ctrl.Result{}, client.IgnoreNotFound(err)
     }
 
+    if !application.DeletionTimestamp.IsZero() {
+        return r.finalize(ctx, &application)
+    }
+
+    if application.Spec.Suspend {
+        if setCondition(&application, v1.SuspendedCondition, metav1.ConditionTrue, "Suspended",
+            "Reconciliation is suspended by spec.suspend") {
+            r.event(&application, corev1.EventTypeNormal, "Suspended", "Reconciliation is suspended")
+        }
+        return ctrl.Result{}, r.Status().Update(ctx, &application)
+    }
+    if meta.RemoveStatusCondition(&application.Status.Conditions, v1.SuspendedCondition) {
+        r.event(&application, corev1.EventTypeNormal, "Resumed", "Reconciliation is resumed")
+    }
+
     var tmpl v1.ApplicationTemplate
 
+    trace.SpanFromContext(ctx).SetAttributes(tracing.ApplicationTemplate.String(application.Spec.Template))
+    _, span := tracing.Start(ctx, "ResolveApplicationTemplate", tracing.ApplicationTemplate.String(application.Spec.Template))
     err = r.Get(ctx, types.NamespacedName{
         Namespace: application.Namespace,
         Name:      application.Spec.Template,
     }, &tmpl)
+    span.SetAttributes(tracing.TemplateRevision.String(tmpl.ResourceVersion))
+    tracing.End(span, err)
 
     if err != nil {
         l.Error(err, "unable to fetch Application Template")
-        return ctrl.Result{}, err
+        return r.fail(ctx, &application, err)
     }
 
     if application.GetOwnerReferences() == nil {
@@ -81,120 +177,822 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
         if err != nil {
             return ctrl.Result{}, err
         }
+        controllerutil.AddFinalizer(&application, ApplicationFinalizer)
+        return ctrl.Result{}, r.Update(ctx, &application)
+    }
+    if controllerutil.AddFinalizer(&application, ApplicationFinalizer) {
         return ctrl.Result{}, r.Update(ctx, &application)
     }
 
-    for _, o := range tmpl.Spec.Objects {
-
-        var deployments v1.ObjectTemplate
-        err = r.Get(ctx, types.NamespacedName{
-            Namespace: application.Namespace,
-            Name:      o.Template,
-        }, &deployments)
+    var objects []render.Object
+    current, rollingBack := rollback(&application)
+    if rollingBack {
+        l.Info("Rolling back", "revision", current)
+        objects, err = r.revisionObjects(ctx, &application, current)
+    } else {
+        objects, err = render.Objects(ctx, render.ClientResolver{Reader: r}, &application, &tmpl)
+        if err == nil {
+            current, err = r.recordRevision(ctx, &application, &tmpl, objects)
+        }
+        if err == nil {
+            if current, rollingBack = automaticRollback(&application, current); rollingBack {
+                l.Info("Rolling back degraded revision", "revision", current)
+                objects, err = r.revisionObjects(ctx, &application, current)
+            }
+        }
+    }
+    if err != nil {
+        l.Error(err, "unable to render Application")
+        return r.fail(ctx, &application, err)
+    }
 
+    resyncInterval := r.ResyncInterval
+    if resyncInterval == 0 {
+        resyncInterval = defaultResyncInterval
+    }
+    now := time.Now()
+    resync := application.Status.LastResyncTime == nil || now.Sub(application.Status.LastResyncTime.Time) >= resyncInterval
+
+    inventory := make([]v1.ManagedObject, 0, len(objects))
+    var manifests []v1.AppliedManifest
+    var conflicts []v1.FieldConflict
+    var skipped []v1.ManagedObject
+    var adopted []v1.ManagedObject
+    var notAdopted []v1.ManagedObject
+    var requeue bool
+    var conflictErr error
+    for _, object := range objects {
+        live := &unstructured.Unstructured{}
+        live.SetGroupVersionKind(object.GroupVersionKind())
+        err = r.Get(ctx, client.ObjectKeyFromObject(object), live)
         if err != nil {
-            l.Error(err, "unable to fetch Deployment Template")
-            return ctrl.Result{}, err
+            if !errors.IsNotFound(err) {
+                return ctrl.Result{}, err
+            }
+            l.Info("Creating object", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+            live = nil
         }
 
-        variables := make(map[string]string)
-
-        for k, v := range o.Variables {
-            variables[k] = v
+        managed := v1.ManagedObject{
+            APIVersion: object.GetAPIVersion(),
+            Kind:       object.GetKind(),
+            Namespace:  object.GetNamespace(),
+            Name:       object.GetName(),
         }
 
-        for k, v := range application.Spec.Variables {
-            variables[k] = v
+        adopting := false
+        if live != nil {
+            owner := metav1.GetControllerOf(live)
+            if owner == nil || owner.UID != application.UID {
+                if !adoptable(&application, live) {
+                    l.Info("Not adopting existing object", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+                    notAdopted = append(notAdopted, managed)
+                    continue
+                }
+                l.Info("Adopting object", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+                adopting = true
+                // Take over every field braid writes from the previous
+                // managers.
+                object.ConflictPolicy = v1.ForceConflictPolicy
+            }
         }
 
-        spec, err := replaceVariables(deployments.Spec.Spec, variables)
+        render.SetOwner(object.Unstructured, &application)
+        hash, err := render.SetHash(object)
         if err != nil {
-            l.Error(err, "unable to create Deployment spec")
-            return ctrl.Result{}, client.IgnoreNotFound(err)
+            return ctrl.Result{}, err
+        }
+        if previous, ok := unchanged(&application, managed, hash, live); ok && !adopting && !resync {
+            l.Info("Skipping unchanged object", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+            manifests = append(manifests, previous)
+            if slices.Contains(application.Status.SkippedUpdates, managed) {
+                skipped = append(skipped, managed)
+            }
+            for _, c := range application.Status.Conflicts {
+                if c.ManagedObject == managed {
+                    conflicts = append(conflicts, c)
+                }
+            }
+            if slices.Contains(application.Status.Adopted, managed) {
+                adopted = append(adopted, managed)
+            }
+            inventory = append(inventory, managed)
+            continue
         }
 
-        object := unstructured.Unstructured{}
-        groupVersion, err := schema.ParseGroupVersion(deployments.Spec.ApiVersion)
-        if err != nil {
-            l.Error(err, "unable to parse GroupVersion")
-            return ctrl.Result{}, client.IgnoreNotFound(err)
+        // Objects that are created again keep the fields ignored on updates.
+        rendered := object
+        rendered.Unstructured = object.DeepCopy()
+        if live != nil {
+            err = ignoreDifferences(object, live)
+            if err != nil {
+                l.Error(err, "unable to ignore differences", "template", object.Template, "kind", object.GetKind())
+                return ctrl.Result{}, err
+            }
         }
-        object.SetGroupVersionKind(groupVersion.WithKind(deployments.Spec.Kind))
 
-        name := types.NamespacedName{Namespace: application.Namespace, Name: application.Name}
-        err = r.Get(ctx, name, &object)
+        var liveVersion string
+        if live != nil {
+            liveVersion = live.GetResourceVersion()
+        }
+        applyCtx, span := tracing.Start(ctx, "Apply",
+            tracing.ObjectTemplate.String(object.Template),
+            tracing.ObjectAPIVersion.String(object.GetAPIVersion()),
+            tracing.ObjectKind.String(object.GetKind()),
+            tracing.ObjectNamespace.String(object.GetNamespace()),
+            tracing.ObjectName.String(object.GetName()),
+            tracing.ApplyStrategy.String(string(applyStrategy(object))),
+        )
+        start := time.Now()
+        found, skip, err := r.write(applyCtx, object, live)
+        var recreating, deleting bool
+        if err != nil && live != nil && object.RecreateOnImmutableChange != nil && apply.Immutable(err) {
+            recreating = true
+            deleting, err = r.recreate(applyCtx, &application, rendered, live, err)
+            if deleting {
+                l.Info("Waiting for object to be deleted before creating it again", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+                requeue = true
+            }
+        }
+        tracing.End(span, err)
+        gvk := metrics.GVK(object.GroupVersionKind())
+        metrics.ApplyDuration.WithLabelValues(gvk...).Observe(time.Since(start).Seconds())
+        if err != nil {
+            metrics.ApplyFailures.WithLabelValues(gvk...).Inc()
+        }
+        if skip {
+            l.Info("Skipping update of create-only object", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+            skipped = append(skipped, managed)
+        }
+        for _, c := range found {
+            conflict := v1.FieldConflict{
+                ManagedObject: managed,
+                Field:         c.Field,
+                Manager:       c.Manager,
+                Policy:        conflictPolicy(object),
+            }
+            if !slices.Contains(application.Status.Conflicts, conflict) {
+                r.event(&application, corev1.EventTypeWarning, "Conflict", "Field %s of %s %s is also managed by %s, handled with the %s policy",
+                    c.Field, object.GetKind(), object.GetName(), c.Manager, conflict.Policy)
+            }
+            conflicts = append(conflicts, conflict)
+        }
 
         if err != nil {
-            if errors.IsNotFound(err) {
-
-                object.SetName(application.Name)
-                object.SetNamespace(application.Namespace)
-                object.SetLabels(make(map[string]string))
-                object.SetAnnotations(make(map[string]string))
-                object.SetOwnerReferences([]metav1.OwnerReference{{
-                    APIVersion: "braid.james-parker.dev/v1",
-                    Kind:       "Application",
-                    Name:       application.Name,
-                    UID:        application.UID,
-                    Controller: ptr.To(true),
-                }})
-
-                object.Object["spec"] = spec
-
-                err = r.Apply(ctx, client.ApplyConfigurationFromUnstructured(&object), &client.ApplyOptions{FieldManager: "braid"})
-
-                if err != nil {
-                    l.Error(err, "unable to create Deployment")
-                    return ctrl.Result{}, err
-                }
-                return ctrl.Result{}, nil
+            l.Error(err, "unable to apply object", "template", object.Template, "kind", object.GetKind())
+            if found == nil {
+                return r.fail(ctx, &application, err)
             }
-            return ctrl.Result{}, err
+            // Apply the remaining objects so every conflict is reported.
+            conflictErr = err
+        }
+
+        if adopting && err == nil {
+            err = r.adopt(ctx, &application, live)
+            if err != nil {
+                l.Error(err, "unable to adopt object", "template", object.Template, "kind", object.GetKind())
+                return ctrl.Result{}, err
+            }
+            r.event(&application, corev1.EventTypeNormal, "Adopted", "Adopted %s %s", object.GetKind(), object.GetName())
+        }
+        if adopting || slices.Contains(application.Status.Adopted, managed) {
+            adopted = append(adopted, managed)
         }
 
-        object = unstructured.Unstructured{}
-        object.SetGroupVersionKind(groupVersion.WithKind(deployments.Spec.Kind))
-        object.SetName(application.Name)
-        object.SetNamespace(application.Namespace)
-        object.SetLabels(make(map[string]string))
-        object.SetAnnotations(make(map[string]string))
+        if err == nil && !skip && !deleting {
+            manifest, err := r.appliedManifest(ctx, managed, hash)
+            if err != nil {
+                return ctrl.Result{}, err
+            }
+            switch {
+            case corrected(&application, manifest, liveVersion):
+                l.Info("Corrected drift", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+                metrics.DriftCorrections.WithLabelValues(gvk...).Inc()
+                r.event(&application, corev1.EventTypeNormal, "DriftCorrected", "Reverted changes made outside braid to %s %s",
+                    object.GetKind(), object.GetName())
+            case live == nil:
+                r.event(&application, corev1.EventTypeNormal, "Created", "Created %s %s", object.GetKind(), object.GetName())
+            case !recreating && !adopting && manifest.ResourceVersion != liveVersion:
+                r.event(&application, corev1.EventTypeNormal, "Updated", "Updated %s %s", object.GetKind(), object.GetName())
+            }
+            manifests = append(manifests, manifest)
+        }
 
-        object.Object["spec"] = spec
+        inventory = append(inventory, managed)
+    }
 
-        err = r.Apply(ctx, client.ApplyConfigurationFromUnstructured(&object), &client.ApplyOptions{FieldManager: "braid"})
+    retained, err := r.prune(ctx, &application, objects)
+    if err != nil {
+        return ctrl.Result{}, err
+    }
 
+    application.Status.Inventory = append(inventory, retained...)
+    application.Status.Retained = retained
+    application.Status.Adopted = adopted
+    application.Status.NotAdopted = notAdopted
+    application.Status.Conflicts = conflicts
+    application.Status.SkippedUpdates = skipped
+    application.Status.Manifests = manifests
+    restarted := application.Status.CurrentRevision != current
+    application.Status.CurrentRevision = current
+    if resync && conflictErr == nil {
+        application.Status.LastResyncTime = &metav1.Time{Time: now}
+    }
+    meta.RemoveStatusCondition(&application.Status.Conditions, v1.StalledCondition)
+    wasReady := meta.IsStatusConditionTrue(application.Status.Conditions, v1.ReadyCondition)
+    switch {
+    case conflictErr != nil:
+        setCondition(&application, v1.ReadyCondition, metav1.ConditionFalse, "Conflict", conflictErr.Error())
+    case requeue:
+        setCondition(&application, v1.ReadyCondition, metav1.ConditionFalse, "Recreating",
+            "Waiting for objects to be deleted before creating them again")
+    default:
+        setCondition(&application, v1.ReadyCondition, metav1.ConditionTrue, "Applied",
+            fmt.Sprintf("Applied %d objects", len(inventory)))
+        if !wasReady {
+            r.event(&application, corev1.EventTypeNormal, "Ready", "Applied %d objects", len(inventory))
+        }
+    }
+    var progressRequeue time.Duration
+    if conflictErr == nil && !requeue {
+        progressRequeue, err = r.progress(ctx, &application, inventory, current, restarted, rollingBack, now)
         if err != nil {
-            l.Error(err, "unable to update Deployment")
             return ctrl.Result{}, err
         }
     }
+    err = r.Status().Update(ctx, &application)
+    if err != nil {
+        return ctrl.Result{}, err
+    }
+
+    requeueAfter := resyncInterval
+    if application.Status.LastResyncTime != nil {
+        requeueAfter = max(application.Status.LastResyncTime.Add(resyncInterval).Sub(now), 0)
+    }
+    if requeue {
+        requeueAfter = min(requeueAfter, recreateRequeue)
+    }
+    if progressRequeue > 0 {
+        requeueAfter = min(requeueAfter, progressRequeue)
+    }
+    return ctrl.Result{RequeueAfter: requeueAfter}, conflictErr
+}
+
+// fail records err on the conditions of application. Errors that only a
+// change to the Application or its templates can fix stall it: they are not
+// retried, and the Application is reconciled again once it or a template
+// changes. Other errors are returned to be retried with backoff.
+func (r *ApplicationReconciler) fail(ctx context.Context, application *v1.Application, err error) (ctrl.Result, error) {
+    reason, permanent := classify(err)
+    // Events are only recorded for new errors, so an Application that keeps
+    // failing the same way does not flood the event stream.
+    if setCondition(application, v1.ReadyCondition, metav1.ConditionFalse, reason, err.Error()) {
+        r.event(application, corev1.EventTypeWarning, reason, "%v", err)
+        var invalid *render.InvalidError
+        if stderrors.As(err, &invalid) {
+            r.templateEvent(ctx, application, invalid)
+        }
+    }
+    if permanent {
+        setCondition(application, v1.StalledCondition, metav1.ConditionTrue, reason, err.Error())
+    } else {
+        meta.RemoveStatusCondition(&application.Status.Conditions, v1.StalledCondition)
+    }
+
+    statusErr := r.Status().Update(ctx, application)
+    if statusErr != nil {
+        logf.FromContext(ctx).Error(statusErr, "unable to update Application status")
+    }
+    if permanent {
+        return ctrl.Result{}, statusErr
+    }
+    return ctrl.Result{}, err
+}
+
+// classify returns the condition reason for err and whether it is permanent:
+// templates that cannot be rendered, objects the API server rejects as
+// invalid and rollbacks to revisions that are not kept fail the same way until
+// they change. Missing templates and other API errors are transient.
+func classify(err error) (string, bool) {
+    switch {
+    case stderrors.Is(err, errRevisionNotFound):
+        return "RevisionNotFound", true
+    case render.IsInvalid(err):
+        return "InvalidTemplate", true
+    case errors.IsInvalid(err), errors.IsBadRequest(err):
+        return "InvalidObject", true
+    case errors.IsNotFound(err):
+        return "DependencyNotFound", false
+    default:
+        return "ReconcileFailed", false
+    }
+}
+
+// templateEvent records an Event on the ObjectTemplate that application
+// could not be rendered with.
+func (r *ApplicationReconciler) templateEvent(ctx context.Context, application *v1.Application, invalid *render.InvalidError) {
+    var tmpl v1.ObjectTemplate
+    err := r.Get(ctx, types.NamespacedName{Namespace: application.Namespace, Name: invalid.Template}, &tmpl)
+    if err != nil {
+        return
+    }
+    r.event(&tmpl, corev1.EventTypeWarning, "RenderFailed", "Application %s: %v", application.Name, invalid.Err)
+}
+
+// setCondition sets a condition of application observed at its current
+// generation. It reports whether the status, reason or message changed.
+func setCondition(application *v1.Application, conditionType string, status metav1.ConditionStatus, reason, message string) bool {
+    previous := meta.FindStatusCondition(application.Status.Conditions, conditionType)
+    changed := previous == nil || previous.Status != status || previous.Reason != reason || previous.Message != message
+    meta.SetStatusCondition(&application.Status.Conditions, metav1.Condition{
+        Type:               conditionType,
+        Status:             status,
+        Reason:             reason,
+        Message:            message,
+        ObservedGeneration: application.Generation,
+    })
+    return changed
+}
+
+// unchanged reports whether live is as braid last applied it for managed,
+// returning the manifest recorded then. Objects are unchanged when the hash
+// of their manifest is the same as when they were applied and they have not
+// been modified since: their generation is compared, or their
+// resourceVersion for kinds without a generation.
+func unchanged(application *v1.Application, managed v1.ManagedObject, hash string, live *unstructured.Unstructured) (v1.AppliedManifest, bool) {
+    if live == nil || live.GetAnnotations()[v1.ManifestHashAnnotation] != hash {
+        return v1.AppliedManifest{}, false
+    }
+    for _, m := range application.Status.Manifests {
+        if m.ManagedObject != managed || m.Hash != hash {
+            continue
+        }
+        if m.Generation != 0 {
+            return m, live.GetGeneration() == m.Generation
+        }
+        return m, m.ResourceVersion != "" && live.GetResourceVersion() == m.ResourceVersion
+    }
+    return v1.AppliedManifest{}, false
+}
+
+// corrected reports whether writing manifest changed an object although its
+// manifest is the one last applied, so the object had been changed outside
+// braid. liveVersion is the resourceVersion the object had before it was
+// written, empty if it did not exist.
+func corrected(application *v1.Application, manifest v1.AppliedManifest, liveVersion string) bool {
+    if liveVersion == "" {
+        return false
+    }
+    for _, m := range application.Status.Manifests {
+        if m.ManagedObject == manifest.ManagedObject {
+            return m.Hash == manifest.Hash && manifest.ResourceVersion != liveVersion
+        }
+    }
+    return false
+}
+
+// appliedManifest records that the manifest with hash has been applied to
+// managed, reading the generation and resourceVersion it has now.
+func (r *ApplicationReconciler) appliedManifest(ctx context.Context, managed v1.ManagedObject, hash string) (v1.AppliedManifest, error) {
+    live := managedObject(managed)
+    err := r.Get(ctx, client.ObjectKeyFromObject(live), live)
+    if err != nil {
+        return v1.AppliedManifest{}, err
+    }
+    return v1.AppliedManifest{
+        ManagedObject:   managed,
+        Hash:            hash,
+        Generation:      live.GetGeneration(),
+        ResourceVersion: live.GetResourceVersion(),
+    }, nil
+}
+
+// recreate deletes live, whose update was rejected with cause because it
+// changes an immutable field, and creates object in its place. It reports
+// whether the old object is still being deleted, in which case object is
+// created by a later reconcile.
+func (r *ApplicationReconciler) recreate(ctx context.Context, application *v1.Application, object render.Object, live *unstructured.Unstructured, cause error) (bool, error) {
+    logf.FromContext(ctx).Info("Recreating object to change immutable fields", "template", object.Template, "kind", object.GetKind(), "name", object.GetName())
+
+    propagation := metav1.DeletePropagationBackground
+    if object.RecreateOnImmutableChange.WaitForDeletion {
+        propagation = metav1.DeletePropagationForeground
+    }
+    err := r.Delete(ctx, live, client.Preconditions{UID: ptr.To(live.GetUID())}, client.PropagationPolicy(propagation))
+    if err != nil && !errors.IsNotFound(err) {
+        return false, err
+    }
+    r.event(application, corev1.EventTypeNormal, "Recreated",
+        "Deleted %s %s to create it again: %v", object.GetKind(), object.GetName(), cause)
+
+    // Objects with finalizers, or deleted in the foreground, stay until they
+    // are released.
+    err = r.Get(ctx, client.ObjectKeyFromObject(live), live.DeepCopy())
+    if err == nil {
+        return true, nil
+    }
+    if !errors.IsNotFound(err) {
+        return false, err
+    }
+
+    object.SetResourceVersion("")
+    _, _, err = r.write(ctx, object, nil)
+    if errors.IsAlreadyExists(err) {
+        return true, nil
+    }
+    return false, err
+}
+
+// event records an Event on object when the reconciler has a Recorder.
+func (r *ApplicationReconciler) event(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+    if r.Recorder != nil {
+        r.Recorder.Eventf(object, eventtype, reason, messageFmt, args...)
+    }
+}
 
-    return ctrl.Result{}, nil
+// write creates or updates object according to its ApplyStrategy. live is
+// the current state of the object, or nil if it does not exist. It returns
+// the apply conflicts found and whether an update was skipped.
+func (r *ApplicationReconciler) write(ctx context.Context, object render.Object, live *unstructured.Unstructured) ([]apply.Conflict, bool, error) {
+    strategy := applyStrategy(object)
+    if strategy == v1.ServerSideApplyStrategy {
+        conflicts, err := r.apply(ctx, object)
+        return conflicts, false, err
+    }
+
+    if live == nil {
+        return nil, false, r.Create(ctx, object.Unstructured, client.FieldOwner("braid"))
+    }
+
+    switch strategy {
+    case v1.CreateOnlyStrategy:
+        changed, err := r.wouldChange(ctx, object, live)
+        return nil, changed, err
+    case v1.ReplaceStrategy:
+        object.SetResourceVersion(live.GetResourceVersion())
+        return nil, false, r.Update(ctx, object.Unstructured, client.FieldOwner("braid"))
+    default:
+        patch, err := json.Marshal(object.Object)
+        if err != nil {
+            return nil, false, err
+        }
+        return nil, false, r.Patch(ctx, live, client.RawPatch(types.MergePatchType, patch), client.FieldOwner("braid"))
+    }
 }
 
-func replaceVariables(spec string, variables map[string]string) (interface{}, error) {
-    tmpl, err := template.New("object").Option("missingkey=zero").Parse(spec)
+// wouldChange reports whether applying object would modify live, using a
+// server-side dry run so fields the API server defaults or rewrites compare
+// equal.
+func (r *ApplicationReconciler) wouldChange(ctx context.Context, object render.Object, live *unstructured.Unstructured) (bool, error) {
+    desired := object.DeepCopy()
+    err := r.Apply(ctx, client.ApplyConfigurationFromUnstructured(desired), &client.ApplyOptions{
+        FieldManager: "braid",
+        Force:        ptr.To(true),
+        DryRun:       []string{metav1.DryRunAll},
+    })
     if err != nil {
-        return struct{}{}, err
+        return false, err
     }
-    buf := new(bytes.Buffer)
-    err = tmpl.Execute(buf, variables)
 
+    return !equality.Semantic.DeepEqual(withoutWriteMetadata(desired), withoutWriteMetadata(live)), nil
+}
+
+// withoutWriteMetadata returns a copy of object without the metadata the API
+// server changes on every write.
+func withoutWriteMetadata(object *unstructured.Unstructured) map[string]interface{} {
+    object = object.DeepCopy()
+    unstructured.RemoveNestedField(object.Object, "metadata", "managedFields")
+    unstructured.RemoveNestedField(object.Object, "metadata", "resourceVersion")
+    unstructured.RemoveNestedField(object.Object, "metadata", "generation")
+    return object.Object
+}
+
+// apply server-side applies object and handles conflicts with other field
+// managers according to its ConflictPolicy. The conflicts are returned
+// whether or not they were resolved.
+func (r *ApplicationReconciler) apply(ctx context.Context, object render.Object) ([]apply.Conflict, error) {
+    err := r.Apply(ctx, client.ApplyConfigurationFromUnstructured(object.Unstructured), &client.ApplyOptions{FieldManager: "braid"})
+    conflicts := apply.Conflicts(err)
+    if conflicts == nil {
+        return nil, err
+    }
+
+    switch conflictPolicy(object) {
+    case v1.ForceConflictPolicy:
+        err = r.Apply(ctx, client.ApplyConfigurationFromUnstructured(object.Unstructured), &client.ApplyOptions{FieldManager: "braid", Force: ptr.To(true)})
+    case v1.SkipFieldConflictPolicy:
+        skipped := object.DeepCopy()
+        for _, c := range conflicts {
+            apply.RemoveField(skipped.Object, c.Field)
+        }
+        err = r.Apply(ctx, client.ApplyConfigurationFromUnstructured(skipped), &client.ApplyOptions{FieldManager: "braid"})
+    }
+
+    return conflicts, err
+}
+
+// ignoreDifferences takes the fields object ignores out of it, so writing it
+// leaves their live values alone. A replacement has to carry every field, so
+// with the Replace strategy the live values are copied into object instead.
+func ignoreDifferences(object render.Object, live *unstructured.Unstructured) error {
+    var paths []string
+    for _, d := range object.IgnoreDifferences {
+        for _, pointer := range d.JSONPointers {
+            path, err := apply.PointerField(pointer, object.Object, live.Object)
+            if err != nil {
+                return err
+            }
+            paths = append(paths, path)
+        }
+        managed, err := apply.ManagedFields(live, d.ManagedFieldsManagers)
+        if err != nil {
+            return err
+        }
+        paths = append(paths, managed...)
+    }
+
+    for _, path := range paths {
+        if applyStrategy(object) == v1.ReplaceStrategy {
+            apply.CopyField(object.Object, live.Object, path)
+        } else {
+            apply.RemoveField(object.Object, path)
+        }
+    }
+    return nil
+}
+
+// applyStrategy returns the ApplyStrategy of object, which is
+// ServerSideApply unless set otherwise.
+func applyStrategy(object render.Object) v1.ApplyStrategy {
+    if object.ApplyStrategy == "" {
+        return v1.ServerSideApplyStrategy
+    }
+    return object.ApplyStrategy
+}
+
+// conflictPolicy returns the ConflictPolicy of object, which is Fail unless
+// set otherwise.
+func conflictPolicy(object render.Object) v1.ConflictPolicy {
+    if object.ConflictPolicy == "" {
+        return v1.FailConflictPolicy
+    }
+    return object.ConflictPolicy
+}
+
+// adoptable reports whether braid may take over live, an existing object that
+// is not controlled by application.
+func adoptable(application *v1.Application, live *unstructured.Unstructured) bool {
+    if metav1.GetControllerOf(live) != nil {
+        return false
+    }
+    switch application.Spec.Adoption {
+    case v1.AlwaysAdoptionPolicy:
+        return true
+    case v1.NeverAdoptionPolicy:
+        return false
+    default:
+        return live.GetLabels()[v1.OrphanedFromLabel] == application.Name
+    }
+}
+
+// adopt completes taking over live once braid has written it: the object is
+// owned and labelled as adopted by application, and the fields braid writes
+// are taken away from their previous managers.
+func (r *ApplicationReconciler) adopt(ctx context.Context, application *v1.Application, live *unstructured.Unstructured) error {
+    err := r.Get(ctx, client.ObjectKeyFromObject(live), live)
+    if err != nil {
+        return err
+    }
+    patch := client.MergeFrom(live.DeepCopy())
+    labels := live.GetLabels()
+    if labels == nil {
+        labels = map[string]string{}
+    }
+    delete(labels, v1.OrphanedFromLabel)
+    labels[v1.AdoptedByLabel] = application.Name
+    live.SetLabels(labels)
+    // CreateOnly objects are not written once they exist, so the owner
+    // reference is added here.
+    if owner := metav1.GetControllerOf(live); owner == nil || owner.UID != application.UID {
+        owned := &unstructured.Unstructured{}
+        render.SetOwner(owned, application)
+        live.SetOwnerReferences(append(live.GetOwnerReferences(), owned.GetOwnerReferences()...))
+    }
+    err = r.Patch(ctx, live, patch, client.FieldOwner("braid"))
     if err != nil {
-        return struct{}{}, err
+        return err
+    }
+
+    // Managed fields set explicitly replace those the API server would
+    // record, so they are written on their own.
+    managedFields, changed, err := apply.TransferFields(live.GetManagedFields(), "braid")
+    if err != nil || !changed {
+        return err
+    }
+    patch = client.MergeFrom(live.DeepCopy())
+    live.SetManagedFields(managedFields)
+    return r.Patch(ctx, live, patch)
+}
+
+// prune releases the objects recorded in the inventory of application that
+// are no longer rendered, returning those that are retained.
+func (r *ApplicationReconciler) prune(ctx context.Context, application *v1.Application, objects []render.Object) ([]v1.ManagedObject, error) {
+    rendered := make(map[string]bool, len(objects))
+    for _, object := range objects {
+        rendered[render.Key(object.Unstructured)] = true
+    }
+
+    var removed []v1.ManagedObject
+    for _, managed := range application.Status.Inventory {
+        if !rendered[render.Key(managedObject(managed))] {
+            removed = append(removed, managed)
+        }
     }
-    var result interface{}
-    err = yaml.Unmarshal(buf.Bytes(), &result)
+    return r.release(ctx, application, removed)
+}
+
+// finalize releases every object of a deleted Application and then removes
+// its finalizer. While objects are retained the Application is kept and
+// checked again after retainedRequeue.
+func (r *ApplicationReconciler) finalize(ctx context.Context, application *v1.Application) (ctrl.Result, error) {
+    if !controllerutil.ContainsFinalizer(application, ApplicationFinalizer) {
+        return ctrl.Result{}, nil
+    }
+
+    retained, err := r.release(ctx, application, application.Status.Inventory)
     if err != nil {
-        return struct{}{}, err
+        return ctrl.Result{}, err
+    }
+    if len(retained) > 0 {
+        logf.FromContext(ctx).Info("Waiting for retained objects to be allowed deletion", "count", len(retained))
+        application.Status.Inventory = retained
+        application.Status.Retained = retained
+        return ctrl.Result{RequeueAfter: retainedRequeue}, r.Status().Update(ctx, application)
+    }
+
+    controllerutil.RemoveFinalizer(application, ApplicationFinalizer)
+    return ctrl.Result{}, r.Update(ctx, application)
+}
+
+// release handles objects braid stops managing according to the deletion
+// policy recorded on them: they are deleted, orphaned, or retained until
+// annotated for deletion. Objects that are not controlled by the Application
+// are left alone. The retained objects are returned.
+func (r *ApplicationReconciler) release(ctx context.Context, application *v1.Application, objects []v1.ManagedObject) ([]v1.ManagedObject, error) {
+    l := logf.FromContext(ctx)
+
+    var retained []v1.ManagedObject
+    for _, managed := range objects {
+        live := managedObject(managed)
+        err := r.Get(ctx, client.ObjectKeyFromObject(live), live)
+        if errors.IsNotFound(err) {
+            continue
+        }
+        if err != nil {
+            return nil, err
+        }
+
+        owner := metav1.GetControllerOf(live)
+        if owner == nil || owner.UID != application.UID {
+            l.Info("Not pruning object controlled by another owner", "kind", managed.Kind, "name", managed.Name)
+            continue
+        }
+
+        policy := v1.DeletionPolicy(live.GetAnnotations()[v1.DeletionPolicyAnnotation])
+        switch policy {
+        case v1.OrphanDeletionPolicy:
+            l.Info("Orphaning object", "kind", managed.Kind, "name", managed.Name)
+            err = r.orphan(ctx, application, live)
+            if err != nil && !errors.IsNotFound(err) {
+                return nil, err
+            }
+            metrics.PrunedObjects.WithLabelValues(string(policy)).Inc()
+            r.event(application, corev1.EventTypeNormal, "Orphaned", "Orphaned %s %s", managed.Kind, managed.Name)
+            continue
+        case v1.RetainDeletionPolicy:
+            if live.GetAnnotations()[v1.AllowDeletionAnnotation] != "true" {
+                l.Info("Retaining object", "kind", managed.Kind, "name", managed.Name)
+                retained = append(retained, managed)
+                continue
+            }
+        }
+
+        l.Info("Pruning object", "kind", managed.Kind, "name", managed.Name)
+        err = r.Delete(ctx, live, client.PropagationPolicy(metav1.DeletePropagationBackground))
+        if err != nil && !errors.IsNotFound(err) {
+            return nil, err
+        }
+        if policy == "" {
+            policy = v1.DeleteDeletionPolicy
+        }
+        metrics.PrunedObjects.WithLabelValues(string(policy)).Inc()
+        r.event(application, corev1.EventTypeNormal, "Pruned", "Deleted %s %s", managed.Kind, managed.Name)
     }
 
-    return result, nil
+    return retained, nil
+}
+
+// orphan removes the owner references to application from live and labels it
+// with the Application it came from, so it survives the Application and can
+// be adopted later.
+func (r *ApplicationReconciler) orphan(ctx context.Context, application *v1.Application, live *unstructured.Unstructured) error {
+    patch := client.MergeFrom(live.DeepCopy())
+
+    var owners []metav1.OwnerReference
+    for _, owner := range live.GetOwnerReferences() {
+        if owner.UID != application.UID {
+            owners = append(owners, owner)
+        }
+    }
+    live.SetOwnerReferences(owners)
+
+    labels := live.GetLabels()
+    if labels == nil {
+        labels = map[string]string{}
+    }
+    labels[v1.OrphanedFromLabel] = application.Name
+    live.SetLabels(labels)
+
+    return r.Patch(ctx, live, patch)
+}
+
+// managedObject returns an object identified by managed, to be read from the
+// cluster.
+func managedObject(managed v1.ManagedObject) *unstructured.Unstructured {
+    object := &unstructured.Unstructured{}
+    object.SetAPIVersion(managed.APIVersion)
+    object.SetKind(managed.Kind)
+    object.SetNamespace(managed.Namespace)
+    object.SetName(managed.Name)
+    return object
 }
 
 func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+    err := metrics.RegisterApplications(mgr.GetClient())
+    if err != nil {
+        return err
+    }
+
+    base, limit := r.BackoffBase, r.BackoffMax
+    if base == 0 {
+        base = defaultBackoffBase
+    }
+    if limit == 0 {
+        limit = defaultBackoffMax
+    }
+
     return ctrl.NewControllerManagedBy(mgr).
         For(&v1.Application{}).
+        // Stalled Applications are reconciled again once a template changes;
+        // changes to the status of a template are ignored.
+        Watches(&v1.ApplicationTemplate{}, handler.EnqueueRequestsFromMapFunc(r.applicationsForApplicationTemplate),
+            builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+        Watches(&v1.ObjectTemplate{}, handler.EnqueueRequestsFromMapFunc(r.applicationsForObjectTemplate),
+            builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+        // Parsed templates are cached until their ObjectTemplate is deleted.
+        Watches(&v1.ObjectTemplate{}, handler.Funcs{
+            DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+                render.Templates.Evict(e.Object.GetUID())
+            },
+        }).
         Named("application").
+        WithOptions(controller.Options{
+            RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](base, limit),
+        }).
         Complete(r)
 }
+
+// applicationsForApplicationTemplate returns a request for every Application
+// using the ApplicationTemplate tmpl.
+func (r *ApplicationReconciler) applicationsForApplicationTemplate(ctx context.Context, tmpl client.Object) []reconcile.Request {
+    var applications v1.ApplicationList
+    err := r.List(ctx, &applications, client.InNamespace(tmpl.GetNamespace()))
+    if err != nil {
+        logf.FromContext(ctx).Error(err, "unable to list Applications")
+        return nil
+    }
+
+    var requests []reconcile.Request
+    for _, application := range applications.Items {
+        if application.Spec.Template == tmpl.GetName() {
+            requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&application)})
+        }
+    }
+    return requests
+}
+
+// applicationsForObjectTemplate returns a request for every Application using
+// an ApplicationTemplate that lists the ObjectTemplate tmpl.
+func (r *ApplicationReconciler) applicationsForObjectTemplate(ctx context.Context, tmpl client.Object) []reconcile.Request {
+    templates, err := referencingApplicationTemplates(ctx, r, tmpl)
+    if err != nil {
+        logf.FromContext(ctx).Error(err, "unable to list ApplicationTemplates")
+        return nil
+    }
+
+    var requests []reconcile.Request
+    for i := range templates {
+        requests = append(requests, r.applicationsForApplicationTemplate(ctx, &templates[i])...)
+    }
+    return requests
+}
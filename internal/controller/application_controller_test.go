@@ -18,17 +18,35 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	braidv1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/metrics"
+	"github.com/james226/braid/internal/tracing"
 )
 
 var _ = Describe("Application Controller", func() {
@@ -204,4 +222,1867 @@ var _ = Describe("Application Controller", func() {
 			Expect(template.Spec.Containers[0].Env).To(Equal([]v1.EnvVar{{Name: "foo", Value: "bar"}}))
 		})
 	})
+
+	Context("When reconciling an application with a multi-document template", func() {
+		const resourceName = "multi-document"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		reconcileApplication := func() {
+			controllerReconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .name }}-config
+data:
+  key: value
+---
+apiVersion: v1
+kind: Service
+spec:
+  ports:
+    - port: 80
+`,
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{
+						{Template: resourceName, Variables: map[string]string{"name": resourceName}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template: resourceName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application and its templates")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+		})
+
+		It("should apply every document and prune the ones that are removed", func() {
+			By("Reconciling once to take ownership and once to apply")
+			reconcileApplication()
+			reconcileApplication()
+
+			configMap := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name: resourceName + "-config", Namespace: "default"}, configMap)).To(Succeed())
+			Expect(metav1.GetControllerOf(configMap).Name).To(Equal(resourceName))
+
+			service := &v1.Service{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, service)).To(Succeed())
+
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Inventory).To(ConsistOf(
+				braidv1.ManagedObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: resourceName + "-config"},
+				braidv1.ManagedObject{APIVersion: "v1", Kind: "Service", Namespace: "default", Name: resourceName},
+			))
+
+			By("Removing the ConfigMap from the template")
+			object := &braidv1.ObjectTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, object)).To(Succeed())
+			object.Spec.Manifests = "apiVersion: v1\nkind: Service\nspec:\n  ports:\n    - port: 80\n"
+			Expect(k8sClient.Update(ctx, object)).To(Succeed())
+			reconcileApplication()
+
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-config", Namespace: "default"}, configMap)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, service)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Inventory).To(HaveLen(1))
+		})
+	})
+
+	Context("When another field manager owns a field of a rendered object", func() {
+		const resourceName = "conflicting"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		reconcileApplication := func() error {
+			controllerReconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		setConflictPolicy := func(policy braidv1.ConflictPolicy) {
+			appTemplate := &braidv1.ApplicationTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, appTemplate)).To(Succeed())
+			appTemplate.Spec.Objects[0].ConflictPolicy = policy
+			Expect(k8sClient.Update(ctx, appTemplate)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: ours\n  other: value\n",
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{Template: resourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template: resourceName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMap")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+		})
+
+		It("should handle the conflict according to the conflict policy", func() {
+			conflict := braidv1.FieldConflict{
+				ManagedObject: braidv1.ManagedObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: resourceName},
+				Field:         ".data.key",
+				Manager:       "kubectl",
+			}
+			configMap := &v1.ConfigMap{}
+			application := &braidv1.Application{}
+
+			By("Reconciling once to take ownership and once to create the ConfigMap")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+
+			By("applying a field of the ConfigMap as another field manager")
+			theirs := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": resourceName, "namespace": "default"},
+				"data":       map[string]interface{}{"key": "theirs"},
+			}}
+			Expect(k8sClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(theirs),
+				&client.ApplyOptions{FieldManager: "kubectl", Force: ptr.To(true)})).To(Succeed())
+
+			By("Failing with the default policy")
+			Expect(reconcileApplication()).To(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "theirs", "other": "value"}))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			conflict.Policy = braidv1.FailConflictPolicy
+			Expect(application.Status.Conflicts).To(ConsistOf(conflict))
+
+			By("Skipping the conflicting field")
+			setConflictPolicy(braidv1.SkipFieldConflictPolicy)
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "theirs", "other": "value"}))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			conflict.Policy = braidv1.SkipFieldConflictPolicy
+			Expect(application.Status.Conflicts).To(ConsistOf(conflict))
+
+			By("Forcing ownership of the conflicting field")
+			setConflictPolicy(braidv1.ForceConflictPolicy)
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "ours", "other": "value"}))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			conflict.Policy = braidv1.ForceConflictPolicy
+			Expect(application.Status.Conflicts).To(ConsistOf(conflict))
+
+			By("Clearing the conflicts once braid owns the field")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Conflicts).To(BeEmpty())
+		})
+	})
+
+	Context("When reconciling objects with different apply strategies", func() {
+		const resourceName = "strategies"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		strategies := map[string]braidv1.ApplyStrategy{
+			"create-only": braidv1.CreateOnlyStrategy,
+			"replace":     braidv1.ReplaceStrategy,
+			"merge-patch": braidv1.MergePatchStrategy,
+		}
+
+		reconcileApplication := func() {
+			controllerReconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		configMapData := func(name string) map[string]string {
+			configMap := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, configMap)).To(Succeed())
+			return configMap.Data
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .name }}\ndata:\n  key: {{ .value }}\n",
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+			}
+			for name, strategy := range strategies {
+				appTemplate.Spec.Objects = append(appTemplate.Spec.Objects, braidv1.ApplicationObject{
+					Template:      resourceName,
+					Variables:     map[string]string{"name": name},
+					ApplyStrategy: strategy,
+				})
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template:  resourceName,
+					Variables: map[string]string{"value": "v1"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMaps")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			for name := range strategies {
+				Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}})).To(Succeed())
+			}
+		})
+
+		It("should create and update each object with its strategy", func() {
+			By("Reconciling once to take ownership and once to create the objects")
+			reconcileApplication()
+			reconcileApplication()
+			for name := range strategies {
+				Expect(configMapData(name)).To(Equal(map[string]string{"key": "v1"}))
+			}
+
+			By("Adding a field outside braid")
+			for name := range strategies {
+				configMap := &v1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, configMap)).To(Succeed())
+				configMap.Data["extra"] = "external"
+				Expect(k8sClient.Update(ctx, configMap)).To(Succeed())
+			}
+			reconcileApplication()
+
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.SkippedUpdates).To(BeEmpty())
+			Expect(configMapData("replace")).To(Equal(map[string]string{"key": "v1"}))
+
+			By("Changing the rendered value")
+			application.Spec.Variables["value"] = "v2"
+			Expect(k8sClient.Update(ctx, application)).To(Succeed())
+			reconcileApplication()
+
+			Expect(configMapData("create-only")).To(Equal(map[string]string{"key": "v1", "extra": "external"}))
+			Expect(configMapData("replace")).To(Equal(map[string]string{"key": "v2"}))
+			Expect(configMapData("merge-patch")).To(Equal(map[string]string{"key": "v2", "extra": "external"}))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.SkippedUpdates).To(ConsistOf(
+				braidv1.ManagedObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "create-only"},
+			))
+			Expect(application.Status.Inventory).To(HaveLen(3))
+		})
+	})
+
+	Context("When a rendered object ignores differences", func() {
+		const resourceName = "ignored"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		reconcileApplication := func() error {
+			controllerReconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: ours\n  tuning: default\n  scale: \"1\"\n",
+					IgnoreDifferences: []braidv1.IgnoreDifference{{
+						JSONPointers: []string{"/data/tuning"},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{
+						Template: resourceName,
+						IgnoreDifferences: []braidv1.IgnoreDifference{{
+							ManagedFieldsManagers: []string{"scaler"},
+						}},
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template: resourceName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMap")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+		})
+
+		It("should leave the ignored fields to other managers", func() {
+			configMap := &v1.ConfigMap{}
+
+			By("Reconciling once to take ownership and once to create the ConfigMap")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "ours", "tuning": "default", "scale": "1"}))
+
+			By("Changing the ignored fields outside braid")
+			configMap.Data["tuning"] = "manual"
+			Expect(k8sClient.Update(ctx, configMap, client.FieldOwner("kubectl"))).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			configMap.Data["scale"] = "5"
+			Expect(k8sClient.Update(ctx, configMap, client.FieldOwner("scaler"))).To(Succeed())
+
+			By("Applying the ConfigMap without them")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "ours", "tuning": "manual", "scale": "5"}))
+
+			By("Keeping the ignored fields when the ConfigMap is replaced")
+			appTemplate := &braidv1.ApplicationTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, appTemplate)).To(Succeed())
+			appTemplate.Spec.Objects[0].ApplyStrategy = braidv1.ReplaceStrategy
+			Expect(k8sClient.Update(ctx, appTemplate)).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "ours", "tuning": "manual", "scale": "5"}))
+		})
+	})
+
+	Context("When rendered objects have deletion policies", func() {
+		const resourceName = "policies"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		policies := map[string]braidv1.DeletionPolicy{
+			"policy-delete": braidv1.DeleteDeletionPolicy,
+			"policy-orphan": braidv1.OrphanDeletionPolicy,
+			"policy-retain": braidv1.RetainDeletionPolicy,
+		}
+
+		reconcileApplication := func() error {
+			controllerReconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		setObjects := func(render bool) {
+			appTemplate := &braidv1.ApplicationTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, appTemplate)).To(Succeed())
+			appTemplate.Spec.Objects = nil
+			if render {
+				for name, policy := range policies {
+					appTemplate.Spec.Objects = append(appTemplate.Spec.Objects, braidv1.ApplicationObject{
+						Template:       resourceName,
+						Variables:      map[string]string{"name": name},
+						DeletionPolicy: policy,
+					})
+				}
+			}
+			Expect(k8sClient.Update(ctx, appTemplate)).To(Succeed())
+		}
+
+		getConfigMap := func(name string) (*v1.ConfigMap, error) {
+			configMap := &v1.ConfigMap{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, configMap)
+			return configMap, err
+		}
+
+		allowDeletion := func(name string) {
+			configMap, err := getConfigMap(name)
+			Expect(err).NotTo(HaveOccurred())
+			configMap.Annotations[braidv1.AllowDeletionAnnotation] = "true"
+			Expect(k8sClient.Update(ctx, configMap)).To(Succeed())
+		}
+
+		expectReleased := func(application *braidv1.Application) {
+			_, err := getConfigMap("policy-delete")
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			orphaned, err := getConfigMap("policy-orphan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphaned.OwnerReferences).To(BeEmpty())
+			Expect(orphaned.Labels).To(HaveKeyWithValue(braidv1.OrphanedFromLabel, resourceName))
+
+			retained, err := getConfigMap("policy-retain")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retained.OwnerReferences).To(HaveLen(1))
+			Expect(retained.OwnerReferences[0].UID).To(Equal(application.UID))
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .name }}\ndata:\n  key: value\n",
+					Variables: []string{"name"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+			setObjects(true)
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template: resourceName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the templates and the ConfigMaps")
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			for name := range policies {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}}))).To(Succeed())
+			}
+		})
+
+		It("should honour the deletion policy when pruning and deleting", func() {
+			application := &braidv1.Application{}
+
+			By("Reconciling once to take ownership and once to create the objects")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Finalizers).To(ContainElement(ApplicationFinalizer))
+			retained, err := getConfigMap("policy-retain")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retained.Annotations).To(HaveKeyWithValue(braidv1.DeletionPolicyAnnotation, "Retain"))
+
+			By("Pruning the objects removed from the template")
+			pruned := func(policy braidv1.DeletionPolicy) float64 {
+				return testutil.ToFloat64(metrics.PrunedObjects.WithLabelValues(string(policy)))
+			}
+			deleted, orphaned := pruned(braidv1.DeleteDeletionPolicy), pruned(braidv1.OrphanDeletionPolicy)
+			setObjects(false)
+			Expect(reconcileApplication()).To(Succeed())
+			expectReleased(application)
+			Expect(pruned(braidv1.DeleteDeletionPolicy)).To(Equal(deleted + 1))
+			Expect(pruned(braidv1.OrphanDeletionPolicy)).To(Equal(orphaned + 1))
+			retainedObject := braidv1.ManagedObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "policy-retain"}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Retained).To(ConsistOf(retainedObject))
+			Expect(application.Status.Inventory).To(ConsistOf(retainedObject))
+
+			By("Deleting the retained object once it is annotated")
+			allowDeletion("policy-retain")
+			Expect(reconcileApplication()).To(Succeed())
+			_, err = getConfigMap("policy-retain")
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Retained).To(BeEmpty())
+			Expect(application.Status.Inventory).To(BeEmpty())
+
+			By("Rendering the objects again")
+			setObjects(true)
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Inventory).To(HaveLen(3))
+
+			By("Keeping the Application until the retained object is annotated")
+			Expect(k8sClient.Delete(ctx, application)).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			expectReleased(application)
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Retained).To(ConsistOf(retainedObject))
+
+			allowDeletion("policy-retain")
+			Expect(reconcileApplication()).To(Succeed())
+			_, err = getConfigMap("policy-retain")
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			err = k8sClient.Get(ctx, typeNamespacedName, application)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When rendered objects already exist", func() {
+		const resourceName = "adoption"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		names := []string{"adopt-free", "adopt-orphan", "adopt-owned"}
+
+		reconcileApplication := func() error {
+			controllerReconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		setAdoption := func(policy braidv1.AdoptionPolicy) *braidv1.Application {
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			application.Spec.Adoption = policy
+			Expect(k8sClient.Update(ctx, application)).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			return application
+		}
+
+		getConfigMap := func(name string) *v1.ConfigMap {
+			configMap := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, configMap)).To(Succeed())
+			return configMap
+		}
+
+		managedObject := func(name string) braidv1.ManagedObject {
+			return braidv1.ManagedObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: name}
+		}
+
+		BeforeEach(func() {
+			By("applying the existing ConfigMaps as another field manager")
+			existing := map[string]map[string]interface{}{
+				"adopt-free":   {"name": "adopt-free", "namespace": "default"},
+				"adopt-orphan": {"name": "adopt-orphan", "namespace": "default", "labels": map[string]interface{}{braidv1.OrphanedFromLabel: resourceName}},
+				"adopt-owned": {"name": "adopt-owned", "namespace": "default", "ownerReferences": []interface{}{map[string]interface{}{
+					"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "other", "uid": "other-uid", "controller": true,
+				}}},
+			}
+			for _, name := range names {
+				configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata":   existing[name],
+					"data":       map[string]interface{}{"key": "theirs", "extra": "kept"},
+				}}
+				Expect(k8sClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(configMap),
+					&client.ApplyOptions{FieldManager: "kubectl"})).To(Succeed())
+			}
+
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .name }}\ndata:\n  key: ours\n",
+					Variables: []string{"name"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+			}
+			for _, name := range names {
+				appTemplate.Spec.Objects = append(appTemplate.Spec.Objects, braidv1.ApplicationObject{
+					Template:  resourceName,
+					Variables: map[string]string{"name": name},
+				})
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template: resourceName,
+					Adoption: braidv1.NeverAdoptionPolicy,
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMaps")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			for _, name := range names {
+				Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}})).To(Succeed())
+			}
+		})
+
+		It("should adopt the objects its adoption policy allows", func() {
+			By("Leaving every existing object alone")
+			Expect(reconcileApplication()).To(Succeed())
+			application := setAdoption(braidv1.NeverAdoptionPolicy)
+			Expect(application.Status.Adopted).To(BeEmpty())
+			Expect(application.Status.NotAdopted).To(ConsistOf(
+				managedObject("adopt-free"), managedObject("adopt-orphan"), managedObject("adopt-owned")))
+			Expect(application.Status.Inventory).To(BeEmpty())
+			for _, name := range names {
+				Expect(getConfigMap(name).Data).To(HaveKeyWithValue("key", "theirs"))
+			}
+
+			By("Adopting the object orphaned by the Application")
+			application = setAdoption(braidv1.OrphanedAdoptionPolicy)
+			Expect(application.Status.Adopted).To(ConsistOf(managedObject("adopt-orphan")))
+			Expect(application.Status.NotAdopted).To(ConsistOf(managedObject("adopt-free"), managedObject("adopt-owned")))
+			orphan := getConfigMap("adopt-orphan")
+			Expect(orphan.Data).To(Equal(map[string]string{"key": "ours", "extra": "kept"}))
+			Expect(orphan.Labels).To(Equal(map[string]string{braidv1.AdoptedByLabel: resourceName}))
+			Expect(metav1.GetControllerOf(orphan).UID).To(Equal(application.UID))
+
+			By("Adopting every object without a controller")
+			application = setAdoption(braidv1.AlwaysAdoptionPolicy)
+			Expect(application.Status.Adopted).To(ConsistOf(managedObject("adopt-orphan"), managedObject("adopt-free")))
+			Expect(application.Status.NotAdopted).To(ConsistOf(managedObject("adopt-owned")))
+			Expect(application.Status.Inventory).To(ConsistOf(managedObject("adopt-orphan"), managedObject("adopt-free")))
+			free := getConfigMap("adopt-free")
+			Expect(free.Data).To(Equal(map[string]string{"key": "ours", "extra": "kept"}))
+			Expect(metav1.GetControllerOf(free).UID).To(Equal(application.UID))
+			for _, entry := range free.ManagedFields {
+				if entry.Manager == "kubectl" {
+					Expect(string(entry.FieldsV1.Raw)).NotTo(ContainSubstring(`"f:key"`))
+					Expect(string(entry.FieldsV1.Raw)).To(ContainSubstring(`"f:extra"`))
+				}
+			}
+			owned := getConfigMap("adopt-owned")
+			Expect(owned.Data).To(HaveKeyWithValue("key", "theirs"))
+			Expect(metav1.GetControllerOf(owned).Name).To(Equal("other"))
+
+			By("Keeping the adopted objects in status")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Adopted).To(ConsistOf(managedObject("adopt-orphan"), managedObject("adopt-free")))
+		})
+	})
+
+	Context("When an update changes an immutable field", func() {
+		const resourceName = "immutable"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		recorder := record.NewFakeRecorder(100)
+
+		reconcileApplication := func() error {
+			controllerReconciler := &ApplicationReconciler{
+				Client:   immutableConfigMaps{k8sClient},
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		setValue := func(value string) {
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			application.Spec.Variables = map[string]string{"value": value}
+			Expect(k8sClient.Update(ctx, application)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\nimmutable: true\ndata:\n  key: {{ .value }}\n",
+					Variables: []string{"value"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{Template: resourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template:  resourceName,
+					Variables: map[string]string{"value": "v1"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMap")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+		})
+
+		It("should re-create the object only when the ObjectTemplate opts in", func() {
+			configMap := &v1.ConfigMap{}
+
+			By("Reconciling once to take ownership and once to create the ConfigMap")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "v1"}))
+			Expect(events(recorder)).To(Equal([]string{
+				"Normal Created Created ConfigMap immutable",
+				"Normal Ready Applied 1 objects",
+			}))
+
+			By("Stalling on the immutable data")
+			setValue("v2")
+			Expect(reconcileApplication()).To(Succeed())
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			stalled := meta.FindStatusCondition(application.Status.Conditions, braidv1.StalledCondition)
+			Expect(stalled).NotTo(BeNil())
+			Expect(stalled.Status).To(Equal(metav1.ConditionTrue))
+			Expect(stalled.Reason).To(Equal("InvalidObject"))
+			Expect(stalled.Message).To(ContainSubstring("field is immutable"))
+			Expect(events(recorder)).To(ConsistOf(HavePrefix("Warning InvalidObject")))
+
+			By("Re-creating the ConfigMap once the ObjectTemplate opts in")
+			object := &braidv1.ObjectTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, object)).To(Succeed())
+			object.Spec.RecreateOnImmutableChange = &braidv1.RecreateOnImmutableChange{}
+			Expect(k8sClient.Update(ctx, object)).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "v2"}))
+			Expect(events(recorder)).To(ConsistOf(
+				HavePrefix("Normal Recreated Deleted ConfigMap immutable to create it again"),
+				Equal("Normal Ready Applied 1 objects"),
+			))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(meta.FindStatusCondition(application.Status.Conditions, braidv1.StalledCondition)).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(application.Status.Conditions, braidv1.ReadyCondition)).To(BeTrue())
+		})
+	})
+
+	Context("When rendered objects have not changed", func() {
+		const resourceName = "unchanged"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		patches := &countingPatches{}
+		recorder := record.NewFakeRecorder(100)
+
+		reconcileApplication := func() error {
+			patches.Client = k8sClient
+			controllerReconciler := &ApplicationReconciler{
+				Client:         patches,
+				Scheme:         k8sClient.Scheme(),
+				Recorder:       recorder,
+				ResyncInterval: time.Hour,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: {{ .value }}\n",
+					Variables: []string{"value"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{
+						Template:      resourceName,
+						ApplyStrategy: braidv1.MergePatchStrategy,
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template:  resourceName,
+					Variables: map[string]string{"value": "v1"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMap")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+		})
+
+		It("should only write objects that changed or are due a resync", func() {
+			configMap := &v1.ConfigMap{}
+			application := &braidv1.Application{}
+
+			By("Reconciling once to take ownership and once to create the ConfigMap")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(patches.count).To(BeZero())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			hash := configMap.Annotations[braidv1.ManifestHashAnnotation]
+			Expect(hash).To(HaveLen(64))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Manifests).To(Equal([]braidv1.AppliedManifest{{
+				ManagedObject: braidv1.ManagedObject{
+					APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: resourceName,
+				},
+				Hash:            hash,
+				ResourceVersion: configMap.ResourceVersion,
+			}}))
+			Expect(application.Status.LastResyncTime).NotTo(BeNil())
+			Expect(events(recorder)).To(Equal([]string{
+				"Normal Created Created ConfigMap unchanged",
+				"Normal Ready Applied 1 objects",
+			}))
+
+			By("Skipping the ConfigMap while nothing changed")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(patches.count).To(BeZero())
+			Expect(events(recorder)).To(BeEmpty())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.Inventory).To(HaveLen(1))
+			Expect(application.Status.Manifests).To(HaveLen(1))
+
+			By("Writing the ConfigMap again once it drifted")
+			drift := metrics.DriftCorrections.WithLabelValues("", "v1", "ConfigMap")
+			corrections := testutil.ToFloat64(drift)
+			configMap.Data["key"] = "drifted"
+			Expect(k8sClient.Update(ctx, configMap)).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(patches.count).To(Equal(1))
+			Expect(testutil.ToFloat64(drift)).To(Equal(corrections + 1))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Data).To(Equal(map[string]string{"key": "v1"}))
+			Expect(events(recorder)).To(Equal([]string{
+				"Normal DriftCorrected Reverted changes made outside braid to ConfigMap unchanged",
+			}))
+
+			By("Writing the ConfigMap again once its manifest changed")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			application.Spec.Variables = map[string]string{"value": "v2"}
+			Expect(k8sClient.Update(ctx, application)).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(patches.count).To(Equal(2))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			Expect(configMap.Annotations[braidv1.ManifestHashAnnotation]).NotTo(Equal(hash))
+			Expect(testutil.ToFloat64(drift)).To(Equal(corrections + 1))
+			Expect(events(recorder)).To(Equal([]string{"Normal Updated Updated ConfigMap unchanged"}))
+
+			By("Writing the ConfigMap again once a resync is due")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(patches.count).To(Equal(2))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			application.Status.LastResyncTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			Expect(k8sClient.Status().Update(ctx, application)).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(patches.count).To(Equal(3))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.LastResyncTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+	})
+
+	Context("When an Application cannot be reconciled", func() {
+		const resourceName = "failing"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		recorder := record.NewFakeRecorder(100)
+		controllerReconciler := &ApplicationReconciler{Recorder: recorder}
+
+		reconcileApplication := func() (reconcile.Result, error) {
+			controllerReconciler.Client = k8sClient
+			controllerReconciler.Scheme = k8sClient.Scheme()
+			return controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+		}
+
+		condition := func(conditionType string) *metav1.Condition {
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			return meta.FindStatusCondition(application.Status.Conditions, conditionType)
+		}
+
+		BeforeEach(func() {
+			By("creating the Application before its templates")
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template:  resourceName,
+					Variables: map[string]string{"value": "v1"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMap")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}}))).To(Succeed())
+		})
+
+		It("should retry transient errors and stall on permanent ones", func() {
+			By("Retrying while the ApplicationTemplate is missing")
+			_, err := reconcileApplication()
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(condition(braidv1.ReadyCondition)).To(HaveField("Reason", "DependencyNotFound"))
+			Expect(condition(braidv1.StalledCondition)).To(BeNil())
+
+			By("Recording a single Event while the error does not change")
+			_, err = reconcileApplication()
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(events(recorder)).To(ConsistOf(HavePrefix("Warning DependencyNotFound")))
+
+			By("Stalling on a template that does not parse")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: {{ .value\n",
+					Variables: []string{"value"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{Template: resourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+			request := reconcile.Request{NamespacedName: typeNamespacedName}
+			Expect(controllerReconciler.applicationsForApplicationTemplate(ctx, appTemplate)).To(ConsistOf(request))
+			Expect(controllerReconciler.applicationsForObjectTemplate(ctx, object)).To(ConsistOf(request))
+
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			result, err := reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+			stalled := condition(braidv1.StalledCondition)
+			Expect(stalled).NotTo(BeNil())
+			Expect(stalled.Status).To(Equal(metav1.ConditionTrue))
+			Expect(stalled.Reason).To(Equal("InvalidTemplate"))
+			Expect(stalled.Message).To(ContainSubstring(`rendering ObjectTemplate "failing"`))
+			Expect(condition(braidv1.ReadyCondition)).To(HaveField("Status", metav1.ConditionFalse))
+			Expect(events(recorder)).To(ConsistOf(
+				HavePrefix(`Warning InvalidTemplate rendering ObjectTemplate "failing"`),
+				HavePrefix("Warning RenderFailed Application failing: "),
+			))
+
+			By("Recovering once the template is fixed")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, object)).To(Succeed())
+			object.Spec.Manifests = "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: {{ .value }}\n"
+			Expect(k8sClient.Update(ctx, object)).To(Succeed())
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(condition(braidv1.StalledCondition)).To(BeNil())
+			Expect(condition(braidv1.ReadyCondition)).To(HaveField("Status", metav1.ConditionTrue))
+			Expect(events(recorder)).To(ContainElement("Normal Ready Applied 1 objects"))
+		})
+	})
+
+	Context("When an Application is rolled back", func() {
+		const resourceName = "rollback"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		reconcileApplication := func() error {
+			controllerReconciler := &ApplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		updateApplication := func(update func(*braidv1.Application)) {
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			update(application)
+			Expect(k8sClient.Update(ctx, application)).To(Succeed())
+		}
+
+		setManifests := func(manifests string) {
+			object := &braidv1.ObjectTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, object)).To(Succeed())
+			object.Spec.Manifests = manifests
+			Expect(k8sClient.Update(ctx, object)).To(Succeed())
+		}
+
+		configMapValue := func() string {
+			configMap := &v1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, configMap)).To(Succeed())
+			return configMap.Data["key"]
+		}
+
+		revisions := func() []int64 {
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			var numbers []int64
+			for _, r := range application.Status.Revisions {
+				numbers = append(numbers, r.Revision)
+			}
+			return numbers
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: {{ .value }}\n",
+					Variables: []string{"value"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{Template: resourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template:             resourceName,
+					Variables:            map[string]string{"value": "v1"},
+					RevisionHistoryLimit: ptr.To[int32](2),
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates, revisions and the ConfigMap")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.DeleteAllOf(ctx, &appsv1.ControllerRevision{}, client.InNamespace("default"),
+				client.MatchingLabels{braidv1.ApplicationLabel: resourceName})).To(Succeed())
+		})
+
+		It("should keep revisions and apply the one rolled back to until the spec changes", func() {
+			By("Recording the first rendering as revision 1")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(configMapValue()).To(Equal("v1"))
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.CurrentRevision).To(Equal(int64(1)))
+			Expect(application.Status.Revisions).To(HaveLen(1))
+			object := &braidv1.ObjectTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, object)).To(Succeed())
+			appTemplate := &braidv1.ApplicationTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, appTemplate)).To(Succeed())
+			Expect(application.Status.Revisions[0].Templates).To(Equal([]braidv1.TemplateRevision{
+				{Kind: "ApplicationTemplate", Name: resourceName, ResourceVersion: appTemplate.ResourceVersion},
+				{Kind: "ObjectTemplate", Name: resourceName, ResourceVersion: object.ResourceVersion},
+			}))
+			controllerRevision := &appsv1.ControllerRevision{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: "default", Name: application.Status.Revisions[0].Name,
+			}, controllerRevision)).To(Succeed())
+			Expect(controllerRevision.Revision).To(Equal(int64(1)))
+			Expect(metav1.IsControlledBy(controllerRevision, application)).To(BeTrue())
+			Expect(string(controllerRevision.Data.Raw)).To(ContainSubstring(`"variables":{"value":"v1"}`))
+
+			By("Recording a revision for every new rendering and deleting the oldest")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(revisions()).To(Equal([]int64{1}))
+			updateApplication(func(a *braidv1.Application) { a.Spec.Variables["value"] = "v2" })
+			Expect(reconcileApplication()).To(Succeed())
+			updateApplication(func(a *braidv1.Application) { a.Spec.Variables["value"] = "v3" })
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(configMapValue()).To(Equal("v3"))
+			Expect(revisions()).To(Equal([]int64{2, 3}))
+
+			By("Applying revision 2 while rollbackTo is set, even if the templates change")
+			updateApplication(func(a *braidv1.Application) { a.Spec.RollbackTo = ptr.To[int64](2) })
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(configMapValue()).To(Equal("v2"))
+			setManifests("apiVersion: v1\nkind: ConfigMap\ndata:\n  key: {{ .value\n")
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(configMapValue()).To(Equal("v2"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.CurrentRevision).To(Equal(int64(2)))
+			Expect(application.Status.Rollback).To(Equal(&braidv1.RollbackStatus{
+				Revision: 2, Generation: application.Generation,
+			}))
+			Expect(revisions()).To(Equal([]int64{2, 3}))
+
+			By("Rendering the templates again once the spec changes")
+			setManifests("apiVersion: v1\nkind: ConfigMap\ndata:\n  key: {{ .value }}\n")
+			updateApplication(func(a *braidv1.Application) { a.Spec.Variables["value"] = "v4" })
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(configMapValue()).To(Equal("v4"))
+			Expect(revisions()).To(Equal([]int64{3, 4}))
+
+			By("Stalling on a revision that is no longer kept")
+			updateApplication(func(a *braidv1.Application) { a.Spec.RollbackTo = ptr.To[int64](1) })
+			Expect(reconcileApplication()).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(meta.FindStatusCondition(application.Status.Conditions, braidv1.StalledCondition)).To(
+				HaveField("Reason", "RevisionNotFound"))
+			Expect(configMapValue()).To(Equal("v4"))
+		})
+	})
+
+	Context("When a revision becomes degraded", func() {
+		const resourceName = "degraded"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		recorder := record.NewFakeRecorder(100)
+
+		reconcileApplication := func() (reconcile.Result, error) {
+			controllerReconciler := &ApplicationReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			return controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+		}
+
+		setTag := func(tag string) {
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			application.Spec.Variables = map[string]string{"tag": tag}
+			Expect(k8sClient.Update(ctx, application)).To(Succeed())
+		}
+
+		deploymentImage := func() string {
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, deployment)).To(Succeed())
+			return deployment.Spec.Template.Spec.Containers[0].Image
+		}
+
+		setDeploymentStatus := func(status appsv1.DeploymentStatus) {
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, deployment)).To(Succeed())
+			deployment.Status = status
+			deployment.Status.ObservedGeneration = deployment.Generation
+			Expect(k8sClient.Status().Update(ctx, deployment)).To(Succeed())
+		}
+
+		condition := func(conditionType string) *metav1.Condition {
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			return meta.FindStatusCondition(application.Status.Conditions, conditionType)
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			object := &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: `apiVersion: apps/v1
+kind: Deployment
+spec:
+  selector:
+    matchLabels: {app: degraded}
+  template:
+    metadata:
+      labels: {app: degraded}
+    spec:
+      containers:
+      - name: web
+        image: nginx:{{ .tag }}
+`,
+					Variables: []string{"tag"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, object)).To(Succeed())
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{Template: resourceName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{
+					Template:                resourceName,
+					Variables:               map[string]string{"tag": "1.27"},
+					ProgressDeadlineSeconds: ptr.To[int32](60),
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates, revisions and the Deployment")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.DeleteAllOf(ctx, &appsv1.ControllerRevision{}, client.InNamespace("default"),
+				client.MatchingLabels{braidv1.ApplicationLabel: resourceName})).To(Succeed())
+		})
+
+		It("should roll back to the last healthy revision", func() {
+			healthy := appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+
+			By("Waiting for the first revision to become healthy")
+			_, err := reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			result, err := reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(healthRequeue))
+			Expect(condition(braidv1.ProgressingCondition)).To(HaveField("Status", metav1.ConditionTrue))
+			Expect(condition(braidv1.DegradedCondition)).To(HaveField("Status", metav1.ConditionFalse))
+			setDeploymentStatus(healthy)
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(condition(braidv1.ProgressingCondition)).To(HaveField("Reason", "Healthy"))
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.LastHealthyRevision).To(Equal(int64(1)))
+			events(recorder)
+
+			By("Rolling back once the second revision is degraded")
+			setDeploymentStatus(appsv1.DeploymentStatus{Replicas: 1, AvailableReplicas: 1})
+			setTag("broken")
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentImage()).To(Equal("nginx:broken"))
+			Expect(condition(braidv1.ProgressingCondition)).To(HaveField("Status", metav1.ConditionTrue))
+			setDeploymentStatus(appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  v1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "degraded-2" has timed out progressing.`,
+			}}})
+			result, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(rollbackRequeue))
+			Expect(condition(braidv1.DegradedCondition)).To(HaveField("Reason", "ObjectDegraded"))
+			rolledBack := condition(braidv1.RolledBackCondition)
+			Expect(rolledBack).NotTo(BeNil())
+			Expect(rolledBack.Status).To(Equal(metav1.ConditionTrue))
+			Expect(rolledBack.Message).To(Equal(`Rolled back from revision 2 to 1: ` +
+				`Deployment degraded: ReplicaSet "degraded-2" has timed out progressing.`))
+			Expect(events(recorder)).To(ConsistOf(
+				HavePrefix("Warning ObjectDegraded Deployment degraded"),
+				"Warning RolledBack "+rolledBack.Message,
+			))
+
+			By("Applying the healthy revision while the templates render the degraded one")
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentImage()).To(Equal("nginx:1.27"))
+			setDeploymentStatus(healthy)
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentImage()).To(Equal("nginx:1.27"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.CurrentRevision).To(Equal(int64(1)))
+			Expect(application.Status.AutomaticRollback).To(Equal(&braidv1.AutomaticRollback{Revision: 1, From: 2}))
+			Expect(meta.IsStatusConditionFalse(application.Status.Conditions, braidv1.DegradedCondition)).To(BeTrue())
+
+			By("Applying a new revision once the templates change")
+			setDeploymentStatus(appsv1.DeploymentStatus{Replicas: 1, AvailableReplicas: 1})
+			setTag("1.28")
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deploymentImage()).To(Equal("nginx:1.28"))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(application.Status.CurrentRevision).To(Equal(int64(3)))
+			Expect(application.Status.AutomaticRollback).To(BeNil())
+			Expect(meta.FindStatusCondition(application.Status.Conditions, braidv1.RolledBackCondition)).To(BeNil())
+
+			By("Rolling back a revision that is still progressing after the deadline")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			progressing := meta.FindStatusCondition(application.Status.Conditions, braidv1.ProgressingCondition)
+			Expect(progressing.Status).To(Equal(metav1.ConditionTrue))
+			progressing.LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+			Expect(k8sClient.Status().Update(ctx, application)).To(Succeed())
+			_, err = reconcileApplication()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(condition(braidv1.DegradedCondition)).To(HaveField("Reason", "ProgressDeadlineExceeded"))
+			Expect(condition(braidv1.RolledBackCondition)).To(HaveField("Message",
+				"Rolled back from revision 3 to 1: Deployment degraded: 0 of 1 replicas updated, "+
+					"60 seconds after revision 3 was applied"))
+		})
+	})
+
+	Context("When an Application is suspended", func() {
+		const resourceName = "suspended"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		recorder := record.NewFakeRecorder(100)
+
+		reconcileApplication := func() {
+			controllerReconciler := &ApplicationReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and a suspended Application")
+			Expect(k8sClient.Create(ctx, &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: braidv1.ObjectTemplateSpec{
+					Manifests: "apiVersion: v1\nkind: ConfigMap\ndata:\n  key: value\n",
+				},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{Template: resourceName}},
+				},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       braidv1.ApplicationSpec{Template: resourceName, Suspend: true},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMap")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.DeleteAllOf(ctx, &appsv1.ControllerRevision{}, client.InNamespace("default"),
+				client.MatchingLabels{braidv1.ApplicationLabel: resourceName})).To(Succeed())
+		})
+
+		It("should not apply the templates until it is resumed", func() {
+			reconcileApplication()
+			reconcileApplication()
+
+			application := &braidv1.Application{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(application.Status.Conditions, braidv1.SuspendedCondition)).To(BeTrue())
+			Expect(application.Status.Inventory).To(BeEmpty())
+			err := k8sClient.Get(ctx, typeNamespacedName, &v1.ConfigMap{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(events(recorder)).To(Equal([]string{"Normal Suspended Reconciliation is suspended"}))
+
+			By("Resuming the Application")
+			application.Spec.Suspend = false
+			Expect(k8sClient.Update(ctx, application)).To(Succeed())
+			reconcileApplication()
+			reconcileApplication()
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &v1.ConfigMap{})).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, application)).To(Succeed())
+			Expect(meta.FindStatusCondition(application.Status.Conditions, braidv1.SuspendedCondition)).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(application.Status.Conditions, braidv1.ReadyCondition)).To(BeTrue())
+			Expect(events(recorder)).To(ContainElement("Normal Resumed Reconciliation is resumed"))
+		})
+	})
+
+	Context("When a reconcile is traced", func() {
+		const resourceName = "traced"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		exporter := tracetest.NewInMemoryExporter()
+
+		reconcileApplication := func() error {
+			controllerReconciler := &ApplicationReconciler{
+				Client:         k8sClient,
+				Scheme:         k8sClient.Scheme(),
+				TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			return err
+		}
+
+		BeforeEach(func() {
+			By("creating the templates and the Application")
+			for _, name := range []string{"traced-a", "traced-b"} {
+				object := &braidv1.ObjectTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: "default",
+					},
+					Spec: braidv1.ObjectTemplateSpec{
+						Manifests: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\n",
+					},
+				}
+				Expect(k8sClient.Create(ctx, object)).To(Succeed())
+			}
+
+			appTemplate := &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationTemplateSpec{
+					Objects: []braidv1.ApplicationObject{{Template: "traced-a"}, {Template: "traced-b"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, appTemplate)).To(Succeed())
+
+			resource := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.ApplicationSpec{Template: resourceName},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the Application, its templates and the ConfigMaps")
+			Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			for _, name := range []string{"traced-a", "traced-b"} {
+				Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}})).To(Succeed())
+				Expect(k8sClient.Delete(ctx, &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}})).To(Succeed())
+			}
+		})
+
+		It("should record a span for every step of the reconcile", func() {
+			By("Reconciling once to take ownership and once to create the ConfigMaps")
+			Expect(reconcileApplication()).To(Succeed())
+			exporter.Reset()
+			Expect(reconcileApplication()).To(Succeed())
+
+			spans := exporter.GetSpans()
+			var root tracetest.SpanStub
+			names := map[string][]string{}
+			for _, span := range spans {
+				if span.Name == "Reconcile" {
+					root = span
+				}
+			}
+			Expect(root.Name).To(Equal("Reconcile"))
+			Expect(root.Attributes).To(ContainElements(
+				tracing.ApplicationNamespace.String("default"),
+				tracing.ApplicationName.String(resourceName),
+				tracing.ApplicationTemplate.String(resourceName),
+			))
+
+			By("Recording the steps as children of the reconcile")
+			for _, span := range spans {
+				if span.Name == "Reconcile" {
+					continue
+				}
+				Expect(span.Parent.SpanID()).To(Equal(root.SpanContext.SpanID()), span.Name)
+				var template string
+				for _, attribute := range span.Attributes {
+					if attribute.Key == tracing.ObjectTemplate || attribute.Key == tracing.ApplicationTemplate {
+						template = attribute.Value.AsString()
+					}
+				}
+				names[span.Name] = append(names[span.Name], template)
+			}
+			Expect(names).To(Equal(map[string][]string{
+				"ResolveApplicationTemplate": {resourceName},
+				"ResolveObjectTemplate":      {"traced-a", "traced-b"},
+				"RenderObjectTemplate":       {"traced-a", "traced-b"},
+				"Apply":                      {"traced-a", "traced-b"},
+			}))
+
+			By("Recording errors on the span that failed")
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: "traced-b", Namespace: "default"}})).To(Succeed())
+			exporter.Reset()
+			Expect(reconcileApplication()).NotTo(Succeed())
+			failed := map[string]codes.Code{}
+			for _, span := range exporter.GetSpans() {
+				failed[span.Name] = span.Status.Code
+			}
+			Expect(failed).To(HaveKeyWithValue("ResolveObjectTemplate", codes.Error))
+			Expect(failed).To(HaveKeyWithValue("Reconcile", codes.Error))
+			Expect(k8sClient.Create(ctx, &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "traced-b", Namespace: "default"},
+				Spec:       braidv1.ObjectTemplateSpec{Manifests: "apiVersion: v1\nkind: ConfigMap\n"},
+			})).To(Succeed())
+		})
+	})
 })
+
+// events drains the Events recorded so far.
+func events(recorder *record.FakeRecorder) []string {
+	var recorded []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			recorded = append(recorded, event)
+		default:
+			return recorded
+		}
+	}
+}
+
+// immutableConfigMaps rejects applies that change the data of an immutable
+// ConfigMap, as the API server does.
+type immutableConfigMaps struct {
+	client.Client
+}
+
+func (c immutableConfigMaps) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	applied := &v1.ConfigMap{}
+	if err := json.Unmarshal(raw, applied); err != nil {
+		return err
+	}
+
+	live := &v1.ConfigMap{}
+	err = c.Get(ctx, client.ObjectKeyFromObject(applied), live)
+	if applied.Kind == "ConfigMap" && err == nil && ptr.Deref(live.Immutable, false) &&
+		!equality.Semantic.DeepEqual(applied.Data, live.Data) {
+		return errors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, applied.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("data"), "field is immutable when `immutable` is set"),
+		})
+	}
+	return c.Client.Apply(ctx, obj, opts...)
+}
+
+// countingPatches counts the patches made through it.
+type countingPatches struct {
+	client.Client
+	count int
+}
+
+func (c *countingPatches) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.count++
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
@@ -18,11 +18,23 @@ package controller
 
 import (
     "context"
-
+    "fmt"
+    "slices"
+    "strings"
+
+    apierrors "k8s.io/apimachinery/pkg/api/errors"
+    "k8s.io/apimachinery/pkg/api/equality"
+    "k8s.io/apimachinery/pkg/api/meta"
+    metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
     "k8s.io/apimachinery/pkg/runtime"
+    "k8s.io/apimachinery/pkg/types"
     ctrl "sigs.k8s.io/controller-runtime"
+    "sigs.k8s.io/controller-runtime/pkg/builder"
     "sigs.k8s.io/controller-runtime/pkg/client"
+    "sigs.k8s.io/controller-runtime/pkg/handler"
     logf "sigs.k8s.io/controller-runtime/pkg/log"
+    "sigs.k8s.io/controller-runtime/pkg/predicate"
+    "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
     v1 "github.com/james226/braid/api/v1"
 )
@@ -33,38 +45,154 @@ type ApplicationTemplateReconciler struct {
     Scheme *runtime.Scheme
 }
 
-// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applications,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applications/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applications/finalizers,verbs=update
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applicationtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applicationtemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applications;objecttemplates,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
+// Reconcile records in the status of an ApplicationTemplate the Applications
+// using it and whether the ObjectTemplates it lists exist.
 func (r *ApplicationTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
     l := logf.FromContext(ctx)
 
     l.Info("Reconcile request", "namespace", req.Namespace, "name", req.Name)
 
-    var application v1.ApplicationTemplate
-    err := r.Get(ctx, req.NamespacedName, &application)
+    var tmpl v1.ApplicationTemplate
+    err := r.Get(ctx, req.NamespacedName, &tmpl)
 
     if err != nil {
-        l.Error(err, "unable to fetch ApplicationTemplate")
         return ctrl.Result{}, client.IgnoreNotFound(err)
     }
 
-    for _, application := range application.GetOwnerReferences() {
-        l.Info("Owner Reference", "APIVersion", application.APIVersion, "Kind", application.Kind, "Name", application.Name)
+    status := v1.ApplicationTemplateStatus{Conditions: slices.Clone(tmpl.Status.Conditions)}
+
+    var applications v1.ApplicationList
+    err = r.List(ctx, &applications, client.InNamespace(tmpl.Namespace))
+    if err != nil {
+        return ctrl.Result{}, err
+    }
+    for _, application := range applications.Items {
+        if application.Spec.Template != tmpl.Name {
+            continue
+        }
+        status.Applications++
+        if meta.IsStatusConditionTrue(application.Status.Conditions, v1.ReadyCondition) {
+            status.ReadyApplications++
+        }
+        if meta.IsStatusConditionTrue(application.Status.Conditions, v1.DegradedCondition) {
+            status.DegradedApplications++
+        }
+        if application.Spec.Suspend {
+            status.SuspendedApplications++
+        }
+        status.Consumers = append(status.Consumers, consumer(&application))
+    }
+    slices.SortFunc(status.Consumers, func(a, b v1.TemplateConsumer) int { return strings.Compare(a.Name, b.Name) })
+
+    var missing []string
+    for _, o := range tmpl.Spec.Objects {
+        if slices.ContainsFunc(status.ObjectTemplates, func(ref v1.ObjectTemplateReference) bool { return ref.Name == o.Template }) {
+            continue
+        }
+        err = r.Get(ctx, types.NamespacedName{Namespace: tmpl.Namespace, Name: o.Template}, &v1.ObjectTemplate{})
+        if client.IgnoreNotFound(err) != nil {
+            return ctrl.Result{}, err
+        }
+        status.ObjectTemplates = append(status.ObjectTemplates, v1.ObjectTemplateReference{Name: o.Template, Found: err == nil})
+        if apierrors.IsNotFound(err) {
+            missing = append(missing, o.Template)
+        }
     }
 
-    return ctrl.Result{}, nil
+    condition := metav1.Condition{
+        Type:               v1.ReadyCondition,
+        Status:             metav1.ConditionTrue,
+        Reason:             "Resolved",
+        Message:            fmt.Sprintf("All %d ObjectTemplates exist", len(status.ObjectTemplates)),
+        ObservedGeneration: tmpl.Generation,
+    }
+    if len(missing) > 0 {
+        condition.Status = metav1.ConditionFalse
+        condition.Reason = "ObjectTemplateNotFound"
+        condition.Message = fmt.Sprintf("ObjectTemplates not found: %s", strings.Join(missing, ", "))
+    }
+    meta.SetStatusCondition(&status.Conditions, condition)
+
+    if equality.Semantic.DeepEqual(status, tmpl.Status) {
+        return ctrl.Result{}, nil
+    }
+    tmpl.Status = status
+    return ctrl.Result{}, r.Status().Update(ctx, &tmpl)
+}
+
+// consumer describes application as a consumer of its ApplicationTemplate,
+// with the resource version of the template its current revision was rendered
+// from.
+func consumer(application *v1.Application) v1.TemplateConsumer {
+    c := v1.TemplateConsumer{Name: application.Name, Revision: application.Status.CurrentRevision}
+    for _, revision := range application.Status.Revisions {
+        if revision.Revision != c.Revision {
+            continue
+        }
+        for _, t := range revision.Templates {
+            if t.Kind == "ApplicationTemplate" && t.Name == application.Spec.Template {
+                c.TemplateResourceVersion = t.ResourceVersion
+            }
+        }
+    }
+    return c
 }
 
 func (r *ApplicationTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
     return ctrl.NewControllerManagedBy(mgr).
         For(&v1.ApplicationTemplate{}).
+        Watches(&v1.Application{}, handler.EnqueueRequestsFromMapFunc(applicationTemplateForApplication)).
+        // Only the existence of ObjectTemplates is reported, so updates that
+        // leave their spec unchanged are ignored.
+        Watches(&v1.ObjectTemplate{}, handler.EnqueueRequestsFromMapFunc(r.applicationTemplatesForObjectTemplate),
+            builder.WithPredicates(predicate.GenerationChangedPredicate{})).
         Named("applicationtemplate").
         Complete(r)
 }
+
+// applicationTemplateForApplication returns a request for the
+// ApplicationTemplate the Application application uses.
+func applicationTemplateForApplication(_ context.Context, application client.Object) []reconcile.Request {
+    name := application.(*v1.Application).Spec.Template
+    return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: application.GetNamespace(), Name: name}}}
+}
+
+// applicationTemplatesForObjectTemplate returns a request for every
+// ApplicationTemplate that lists the ObjectTemplate tmpl.
+func (r *ApplicationTemplateReconciler) applicationTemplatesForObjectTemplate(ctx context.Context, tmpl client.Object) []reconcile.Request {
+    templates, err := referencingApplicationTemplates(ctx, r, tmpl)
+    if err != nil {
+        logf.FromContext(ctx).Error(err, "unable to list ApplicationTemplates")
+        return nil
+    }
+
+    requests := make([]reconcile.Request, len(templates))
+    for i := range templates {
+        requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&templates[i])}
+    }
+    return requests
+}
+
+// referencingApplicationTemplates returns the ApplicationTemplates that list
+// the ObjectTemplate tmpl.
+func referencingApplicationTemplates(ctx context.Context, reader client.Reader, tmpl client.Object) ([]v1.ApplicationTemplate, error) {
+    var templates v1.ApplicationTemplateList
+    err := reader.List(ctx, &templates, client.InNamespace(tmpl.GetNamespace()))
+    if err != nil {
+        return nil, err
+    }
+
+    var referencing []v1.ApplicationTemplate
+    for _, applicationTemplate := range templates.Items {
+        if slices.ContainsFunc(applicationTemplate.Spec.Objects, func(o v1.ApplicationObject) bool {
+            return o.Template == tmpl.GetName()
+        }) {
+            referencing = append(referencing, applicationTemplate)
+        }
+    }
+    return referencing, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	braidv1 "github.com/james226/braid/api/v1"
+)
+
+var _ = Describe("ApplicationTemplate Controller", func() {
+	Context("When reconciling an ApplicationTemplate used by Applications", func() {
+		const resourceName = "consumed"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		reconcileTemplate := func() *braidv1.ApplicationTemplate {
+			controllerReconciler := &ApplicationTemplateReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			tmpl := &braidv1.ApplicationTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tmpl)).To(Succeed())
+			return tmpl
+		}
+
+		createApplication := func(name, template string, suspend bool, status braidv1.ApplicationStatus) {
+			application := &braidv1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       braidv1.ApplicationSpec{Template: template, Suspend: suspend},
+			}
+			Expect(k8sClient.Create(ctx, application)).To(Succeed())
+			application.Status = status
+			Expect(k8sClient.Status().Update(ctx, application)).To(Succeed())
+		}
+
+		revision := func(number int64, resourceVersion string) braidv1.ApplicationRevision {
+			return braidv1.ApplicationRevision{Revision: number, Templates: []braidv1.TemplateRevision{
+				{Kind: "ApplicationTemplate", Name: resourceName, ResourceVersion: resourceVersion},
+				{Kind: "ObjectTemplate", Name: "consumed-configmap", ResourceVersion: "7"},
+			}}
+		}
+
+		condition := func(conditionType string, status metav1.ConditionStatus) metav1.Condition {
+			return metav1.Condition{Type: conditionType, Status: status, Reason: "Test",
+				LastTransitionTime: metav1.Now()}
+		}
+
+		BeforeEach(func() {
+			By("creating the ApplicationTemplate, one of its ObjectTemplates and the Applications")
+			Expect(k8sClient.Create(ctx, &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "consumed-configmap", Namespace: "default"},
+				Spec:       braidv1.ObjectTemplateSpec{ApiVersion: "v1", Kind: "ConfigMap"},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: braidv1.ApplicationTemplateSpec{Objects: []braidv1.ApplicationObject{
+					{Template: "consumed-configmap"},
+					{Template: "consumed-secret"},
+					{Template: "consumed-configmap"},
+				}},
+			})).To(Succeed())
+
+			createApplication("consumer-a", resourceName, false, braidv1.ApplicationStatus{
+				Conditions:      []metav1.Condition{condition(braidv1.ReadyCondition, metav1.ConditionTrue)},
+				CurrentRevision: 2,
+				Revisions:       []braidv1.ApplicationRevision{revision(1, "3"), revision(2, "5")},
+			})
+			createApplication("consumer-b", resourceName, true, braidv1.ApplicationStatus{
+				Conditions: []metav1.Condition{
+					condition(braidv1.ReadyCondition, metav1.ConditionTrue),
+					condition(braidv1.DegradedCondition, metav1.ConditionTrue),
+				},
+				CurrentRevision: 1,
+				Revisions:       []braidv1.ApplicationRevision{revision(1, "3"), revision(2, "5")},
+			})
+			createApplication("consumer-c", resourceName, false, braidv1.ApplicationStatus{})
+			createApplication("other", "other-template", false, braidv1.ApplicationStatus{})
+		})
+
+		AfterEach(func() {
+			By("Cleanup the templates and Applications")
+			for _, name := range []string{"consumer-a", "consumer-b", "consumer-c", "other"} {
+				Expect(k8sClient.Delete(ctx, &braidv1.Application{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}})).To(Succeed())
+			}
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			for _, name := range []string{"consumed-configmap", "consumed-secret"} {
+				Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}})).To(Succeed())
+			}
+		})
+
+		It("should report its consumers and ObjectTemplates", func() {
+			tmpl := reconcileTemplate()
+			Expect(tmpl.Status.Applications).To(Equal(int32(3)))
+			Expect(tmpl.Status.ReadyApplications).To(Equal(int32(2)))
+			Expect(tmpl.Status.DegradedApplications).To(Equal(int32(1)))
+			Expect(tmpl.Status.SuspendedApplications).To(Equal(int32(1)))
+			Expect(tmpl.Status.Consumers).To(Equal([]braidv1.TemplateConsumer{
+				{Name: "consumer-a", Revision: 2, TemplateResourceVersion: "5"},
+				{Name: "consumer-b", Revision: 1, TemplateResourceVersion: "3"},
+				{Name: "consumer-c"},
+			}))
+			Expect(tmpl.Status.ObjectTemplates).To(Equal([]braidv1.ObjectTemplateReference{
+				{Name: "consumed-configmap", Found: true},
+				{Name: "consumed-secret", Found: false},
+			}))
+			ready := meta.FindStatusCondition(tmpl.Status.Conditions, braidv1.ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+			Expect(ready.Reason).To(Equal("ObjectTemplateNotFound"))
+			Expect(ready.Message).To(Equal("ObjectTemplates not found: consumed-secret"))
+
+			By("Leaving an unchanged status alone")
+			Expect(reconcileTemplate().ResourceVersion).To(Equal(tmpl.ResourceVersion))
+
+			By("Creating the missing ObjectTemplate")
+			Expect(k8sClient.Create(ctx, &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "consumed-secret", Namespace: "default"},
+				Spec:       braidv1.ObjectTemplateSpec{ApiVersion: "v1", Kind: "Secret"},
+			})).To(Succeed())
+			tmpl = reconcileTemplate()
+			Expect(tmpl.Status.ObjectTemplates).To(ContainElement(braidv1.ObjectTemplateReference{
+				Name: "consumed-secret", Found: true}))
+			Expect(meta.IsStatusConditionTrue(tmpl.Status.Conditions, braidv1.ReadyCondition)).To(BeTrue())
+		})
+	})
+})
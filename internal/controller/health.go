@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/health"
+)
+
+// healthRequeue is how often an Application is checked again while its
+// objects are progressing.
+const healthRequeue = 10 * time.Second
+
+// rollbackRequeue is how soon an Application is reconciled again to apply
+// the revision it was rolled back to.
+const rollbackRequeue = time.Second
+
+// health returns the health of the objects in inventory, naming the object
+// that is not healthy. Degraded objects take precedence over progressing
+// ones.
+func (r *ApplicationReconciler) health(ctx context.Context, inventory []v1.ManagedObject) (health.Result, error) {
+	result := health.Result{
+		Status:  health.Healthy,
+		Message: fmt.Sprintf("%d objects are healthy", len(inventory)),
+	}
+	for _, managed := range inventory {
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion(managed.APIVersion)
+		object.SetKind(managed.Kind)
+		err := r.Get(ctx, types.NamespacedName{Namespace: managed.Namespace, Name: managed.Name}, object)
+		if err != nil {
+			return health.Result{}, err
+		}
+		assessed := health.Assess(object)
+		assessed.Message = fmt.Sprintf("%s %s: %s", managed.Kind, managed.Name, assessed.Message)
+		switch {
+		case assessed.Status == health.Degraded:
+			return assessed, nil
+		case assessed.Status == health.Progressing && result.Status == health.Healthy:
+			result = assessed
+		}
+	}
+	return result, nil
+}
+
+// progress records the health of the objects in inventory, applied for
+// revision current of application, in its Progressing and Degraded
+// conditions. The progress deadline starts when restarted is true, because
+// a new revision was applied. A degraded revision is rolled back to the last
+// healthy one unless a rollback is already in effect. progress returns when
+// application should be checked again, or zero.
+func (r *ApplicationReconciler) progress(ctx context.Context, application *v1.Application, inventory []v1.ManagedObject,
+	current int64, restarted, rollingBack bool, now time.Time) (time.Duration, error) {
+	result, err := r.health(ctx, inventory)
+	if err != nil {
+		return 0, err
+	}
+
+	if restarted {
+		meta.RemoveStatusCondition(&application.Status.Conditions, v1.ProgressingCondition)
+	}
+	reason := "ObjectDegraded"
+	switch result.Status {
+	case health.Healthy:
+		setCondition(application, v1.ProgressingCondition, metav1.ConditionFalse, "Healthy", result.Message)
+		setCondition(application, v1.DegradedCondition, metav1.ConditionFalse, "Healthy", result.Message)
+		application.Status.LastHealthyRevision = current
+		return 0, nil
+	case health.Progressing:
+		setCondition(application, v1.ProgressingCondition, metav1.ConditionTrue, "Progressing", result.Message)
+		deadline := application.Spec.ProgressDeadlineSeconds
+		if deadline == nil {
+			setCondition(application, v1.DegradedCondition, metav1.ConditionFalse, "Progressing", result.Message)
+			return healthRequeue, nil
+		}
+		since := meta.FindStatusCondition(application.Status.Conditions, v1.ProgressingCondition).LastTransitionTime
+		remaining := since.Add(time.Duration(*deadline) * time.Second).Sub(now)
+		if remaining > 0 {
+			setCondition(application, v1.DegradedCondition, metav1.ConditionFalse, "Progressing", result.Message)
+			return min(healthRequeue, remaining), nil
+		}
+		reason = "ProgressDeadlineExceeded"
+		result.Message = fmt.Sprintf("%s, %d seconds after revision %d was applied", result.Message, *deadline, current)
+	default:
+		setCondition(application, v1.ProgressingCondition, metav1.ConditionFalse, "Degraded", result.Message)
+	}
+
+	if setCondition(application, v1.DegradedCondition, metav1.ConditionTrue, reason, result.Message) {
+		r.event(application, corev1.EventTypeWarning, reason, "%s", result.Message)
+	}
+	if rollingBack || !r.rollBack(application, current, reason, result.Message) {
+		return 0, nil
+	}
+	return rollbackRequeue, nil
+}
+
+// rollBack rolls application back from revision current to the last healthy
+// revision, if it has a progress deadline and that revision is still kept.
+func (r *ApplicationReconciler) rollBack(application *v1.Application, current int64, reason, message string) bool {
+	healthy := application.Status.LastHealthyRevision
+	if application.Spec.ProgressDeadlineSeconds == nil || healthy == 0 || healthy == current {
+		return false
+	}
+	if !slices.ContainsFunc(application.Status.Revisions, func(r v1.ApplicationRevision) bool { return r.Revision == healthy }) {
+		return false
+	}
+	application.Status.AutomaticRollback = &v1.AutomaticRollback{Revision: healthy, From: current}
+	message = fmt.Sprintf("Rolled back from revision %d to %d: %s", current, healthy, message)
+	setCondition(application, v1.RolledBackCondition, metav1.ConditionTrue, reason, message)
+	r.event(application, corev1.EventTypeWarning, "RolledBack", "%s", message)
+	return true
+}
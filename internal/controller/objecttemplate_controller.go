@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+// ObjectTemplateReconciler reconciles a ObjectTemplate object
+type ObjectTemplateReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=objecttemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=objecttemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=applicationtemplates,verbs=get;list;watch
+
+// Reconcile parses the body of an ObjectTemplate and records in its status
+// whether it is valid, the variables it references and the
+// ApplicationTemplates that list it.
+func (r *ObjectTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var tmpl v1.ObjectTemplate
+	err := r.Get(ctx, req.NamespacedName, &tmpl)
+
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := v1.ObjectTemplateStatus{Conditions: slices.Clone(tmpl.Status.Conditions)}
+
+	condition := metav1.Condition{
+		Type:               v1.ValidCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Parsed",
+		Message:            "The template parses",
+		ObservedGeneration: tmpl.Generation,
+	}
+	status.ReferencedVariables, err = parse(&tmpl)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ParseError"
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	templates, err := referencingApplicationTemplates(ctx, r, &tmpl)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, t := range templates {
+		status.ApplicationTemplates = append(status.ApplicationTemplates, t.Name)
+	}
+	slices.Sort(status.ApplicationTemplates)
+
+	if equality.Semantic.DeepEqual(status, tmpl.Status) {
+		return ctrl.Result{}, nil
+	}
+	tmpl.Status = status
+	return ctrl.Result{}, r.Status().Update(ctx, &tmpl)
+}
+
+// parse parses the body of tmpl with its engine and returns the sorted names
+// of the variables it references.
+func parse(tmpl *v1.ObjectTemplate) ([]string, error) {
+	body := tmpl.Spec.Spec
+	if tmpl.Spec.Manifests != "" {
+		body = tmpl.Spec.Manifests
+	}
+	engine, err := render.EngineFor(tmpl.Spec.Engine)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := engine.Parse(body); err != nil {
+		return nil, err
+	}
+	return engine.Variables(body)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ObjectTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1.ObjectTemplate{}).
+		// Both the old and the new ObjectTemplates of an updated
+		// ApplicationTemplate are enqueued, so removed references are noticed.
+		Watches(&v1.ApplicationTemplate{}, handler.EnqueueRequestsFromMapFunc(objectTemplatesForApplicationTemplate)).
+		Named("objecttemplate").
+		Complete(r)
+}
+
+// objectTemplatesForApplicationTemplate returns a request for every
+// ObjectTemplate the ApplicationTemplate tmpl lists.
+func objectTemplatesForApplicationTemplate(_ context.Context, tmpl client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, o := range tmpl.(*v1.ApplicationTemplate).Spec.Objects {
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: tmpl.GetNamespace(), Name: o.Template}}
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
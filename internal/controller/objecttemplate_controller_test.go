@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	braidv1 "github.com/james226/braid/api/v1"
+)
+
+var _ = Describe("ObjectTemplate Controller", func() {
+	Context("When reconciling an ObjectTemplate", func() {
+		const resourceName = "validated"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		reconcileTemplate := func() *braidv1.ObjectTemplate {
+			controllerReconciler := &ObjectTemplateReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			tmpl := &braidv1.ObjectTemplate{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, tmpl)).To(Succeed())
+			return tmpl
+		}
+
+		BeforeEach(func() {
+			By("creating the ObjectTemplate and the ApplicationTemplates listing it")
+			Expect(k8sClient.Create(ctx, &braidv1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: braidv1.ObjectTemplateSpec{
+					ApiVersion: "apps/v1",
+					Kind:       "Deployment",
+					Spec:       "replicas: {{ .replicas }}\nimage: nginx:{{ .tag }}\nname: {{ .tag }}\n",
+					Variables:  []string{"tag"},
+				},
+			})).To(Succeed())
+			for _, name := range []string{"validated-web", "validated-api", "validated-other"} {
+				objects := []braidv1.ApplicationObject{{Template: resourceName}, {Template: resourceName}}
+				if name == "validated-other" {
+					objects = []braidv1.ApplicationObject{{Template: "another"}}
+				}
+				Expect(k8sClient.Create(ctx, &braidv1.ApplicationTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec:       braidv1.ApplicationTemplateSpec{Objects: objects},
+				})).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			By("Cleanup the templates")
+			Expect(k8sClient.Delete(ctx, &braidv1.ObjectTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			for _, name := range []string{"validated-web", "validated-api", "validated-other"} {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+					Name: name, Namespace: "default"}}))).To(Succeed())
+			}
+		})
+
+		It("should report whether it parses, its variables and its ApplicationTemplates", func() {
+			tmpl := reconcileTemplate()
+			valid := meta.FindStatusCondition(tmpl.Status.Conditions, braidv1.ValidCondition)
+			Expect(valid).NotTo(BeNil())
+			Expect(valid.Status).To(Equal(metav1.ConditionTrue))
+			Expect(tmpl.Status.ReferencedVariables).To(Equal([]string{"replicas", "tag"}))
+			Expect(tmpl.Status.ApplicationTemplates).To(Equal([]string{"validated-api", "validated-web"}))
+
+			By("Leaving an unchanged status alone")
+			Expect(reconcileTemplate().ResourceVersion).To(Equal(tmpl.ResourceVersion))
+
+			By("Breaking the template")
+			tmpl.Spec.Spec = "image: nginx:{{ .tag\n"
+			Expect(k8sClient.Update(ctx, tmpl)).To(Succeed())
+			tmpl = reconcileTemplate()
+			valid = meta.FindStatusCondition(tmpl.Status.Conditions, braidv1.ValidCondition)
+			Expect(valid.Status).To(Equal(metav1.ConditionFalse))
+			Expect(valid.Reason).To(Equal("ParseError"))
+			Expect(valid.Message).To(ContainSubstring("unclosed action"))
+			Expect(tmpl.Status.ReferencedVariables).To(BeEmpty())
+
+			By("Removing an ApplicationTemplate that lists it")
+			Expect(k8sClient.Delete(ctx, &braidv1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{
+				Name: "validated-api", Namespace: "default"}})).To(Succeed())
+			tmpl = reconcileTemplate()
+			Expect(tmpl.Status.ApplicationTemplates).To(Equal([]string{"validated-web"}))
+		})
+
+		It("should enqueue each ObjectTemplate an ApplicationTemplate lists once", func() {
+			Expect(objectTemplatesForApplicationTemplate(ctx, &braidv1.ApplicationTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "validated-web", Namespace: "default"},
+				Spec: braidv1.ApplicationTemplateSpec{Objects: []braidv1.ApplicationObject{
+					{Template: resourceName}, {Template: "another"}, {Template: resourceName},
+				}},
+			})).To(Equal([]reconcile.Request{
+				{NamespacedName: typeNamespacedName},
+				{NamespacedName: types.NamespacedName{Name: "another", Namespace: "default"}},
+			}))
+		})
+	})
+})
@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+// errRevisionNotFound is returned when spec.rollbackTo names a revision that
+// is not kept.
+var errRevisionNotFound = errors.New("revision not found")
+
+// revision is the data of the ControllerRevision holding a revision of the
+// rendered manifests of an Application.
+type revision struct {
+	Variables map[string]string     `json:"variables,omitempty"`
+	Templates []v1.TemplateRevision `json:"templates"`
+	Manifests []revisionManifest    `json:"manifests"`
+}
+
+// revisionManifest is a rendered object and the settings it is applied with.
+type revisionManifest struct {
+	Template                  string                        `json:"template"`
+	TemplateVersion           string                        `json:"templateVersion,omitempty"`
+	ConflictPolicy            v1.ConflictPolicy             `json:"conflictPolicy,omitempty"`
+	ApplyStrategy             v1.ApplyStrategy              `json:"applyStrategy,omitempty"`
+	IgnoreDifferences         []v1.IgnoreDifference         `json:"ignoreDifferences,omitempty"`
+	RecreateOnImmutableChange *v1.RecreateOnImmutableChange `json:"recreateOnImmutableChange,omitempty"`
+	Object                    map[string]any                `json:"object"`
+}
+
+// rollback returns the revision spec.rollbackTo asks for and whether the
+// rollback is still in effect, recording it in the status of application.
+func rollback(application *v1.Application) (int64, bool) {
+	to := application.Spec.RollbackTo
+	if to == nil {
+		application.Status.Rollback = nil
+		return 0, false
+	}
+	if application.Status.Rollback == nil || application.Status.Rollback.Revision != *to {
+		application.Status.Rollback = &v1.RollbackStatus{Revision: *to, Generation: application.Generation}
+	}
+	return *to, application.Status.Rollback.Generation == application.Generation
+}
+
+// automaticRollback returns the revision to apply instead of the rendered
+// revision while the templates render the degraded revision application was
+// rolled back from. The rollback ends once they render another revision.
+func automaticRollback(application *v1.Application, rendered int64) (int64, bool) {
+	rollback := application.Status.AutomaticRollback
+	if rollback == nil {
+		return rendered, false
+	}
+	if rollback.From != rendered {
+		application.Status.AutomaticRollback = nil
+		meta.RemoveStatusCondition(&application.Status.Conditions, v1.RolledBackCondition)
+		return rendered, false
+	}
+	return rollback.Revision, true
+}
+
+// revisions lists the ControllerRevisions of application, oldest first.
+func (r *ApplicationReconciler) revisions(ctx context.Context, application *v1.Application) ([]appsv1.ControllerRevision, error) {
+	var list appsv1.ControllerRevisionList
+	err := r.List(ctx, &list, client.InNamespace(application.Namespace),
+		client.MatchingLabels{v1.ApplicationLabel: application.Name})
+	if err != nil {
+		return nil, err
+	}
+	revisions := slices.DeleteFunc(list.Items, func(c appsv1.ControllerRevision) bool {
+		return !metav1.IsControlledBy(&c, application)
+	})
+	slices.SortFunc(revisions, func(a, b appsv1.ControllerRevision) int {
+		return cmp.Compare(a.Revision, b.Revision)
+	})
+	return revisions, nil
+}
+
+// revisionObjects returns the objects stored in revision number of
+// application.
+func (r *ApplicationReconciler) revisionObjects(ctx context.Context, application *v1.Application, number int64) ([]render.Object, error) {
+	revisions, err := r.revisions(ctx, application)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(revisions, func(c appsv1.ControllerRevision) bool { return c.Revision == number })
+	if i < 0 {
+		return nil, fmt.Errorf("rolling back to revision %d: %w", number, errRevisionNotFound)
+	}
+	var data revision
+	if err := json.Unmarshal(revisions[i].Data.Raw, &data); err != nil {
+		return nil, fmt.Errorf("reading revision %d: %w", number, err)
+	}
+	objects := make([]render.Object, len(data.Manifests))
+	for i, m := range data.Manifests {
+		objects[i] = render.Object{
+			Template:                  m.Template,
+			TemplateVersion:           m.TemplateVersion,
+			ConflictPolicy:            m.ConflictPolicy,
+			ApplyStrategy:             m.ApplyStrategy,
+			IgnoreDifferences:         m.IgnoreDifferences,
+			RecreateOnImmutableChange: m.RecreateOnImmutableChange,
+			Unstructured:              &unstructured.Unstructured{Object: m.Object},
+		}
+	}
+	return objects, nil
+}
+
+// recordRevision stores objects, rendered from tmpl, as the newest revision
+// of application and returns its number. A revision with the same manifests
+// is reused rather than stored again. Revisions beyond the history limit of
+// application are deleted, and those that are kept are listed in its status.
+func (r *ApplicationReconciler) recordRevision(ctx context.Context, application *v1.Application, tmpl *v1.ApplicationTemplate, objects []render.Object) (int64, error) {
+	data := revision{
+		Variables: application.Spec.Variables,
+		Templates: []v1.TemplateRevision{{Kind: "ApplicationTemplate", Name: tmpl.Name, ResourceVersion: tmpl.ResourceVersion}},
+		Manifests: make([]revisionManifest, len(objects)),
+	}
+	for i, o := range objects {
+		data.Manifests[i] = revisionManifest{
+			Template:                  o.Template,
+			TemplateVersion:           o.TemplateVersion,
+			ConflictPolicy:            o.ConflictPolicy,
+			ApplyStrategy:             o.ApplyStrategy,
+			IgnoreDifferences:         o.IgnoreDifferences,
+			RecreateOnImmutableChange: o.RecreateOnImmutableChange,
+			Object:                    o.Object,
+		}
+		if !slices.ContainsFunc(data.Templates, func(t v1.TemplateRevision) bool {
+			return t.Kind == "ObjectTemplate" && t.Name == o.Template
+		}) {
+			data.Templates = append(data.Templates, v1.TemplateRevision{Kind: "ObjectTemplate", Name: o.Template, ResourceVersion: o.TemplateVersion})
+		}
+	}
+	manifests, err := json.Marshal(data.Manifests)
+	if err != nil {
+		return 0, err
+	}
+	sum := sha256.Sum256(manifests)
+	name := fmt.Sprintf("%s-%s", application.Name, hex.EncodeToString(sum[:])[:10])
+
+	revisions, err := r.revisions(ctx, application)
+	if err != nil {
+		return 0, err
+	}
+	var latest int64
+	if len(revisions) > 0 {
+		latest = revisions[len(revisions)-1].Revision
+	}
+
+	i := slices.IndexFunc(revisions, func(c appsv1.ControllerRevision) bool { return c.Name == name })
+	switch {
+	case i >= 0 && i == len(revisions)-1:
+	case i >= 0:
+		// The manifests of an older revision are applied again, which makes
+		// it the newest revision.
+		current := revisions[i]
+		current.Revision = latest + 1
+		if err := r.Update(ctx, &current); err != nil {
+			return 0, err
+		}
+		revisions = append(slices.Delete(revisions, i, i+1), current)
+	default:
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return 0, err
+		}
+		current := appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: application.Namespace,
+				Labels:    map[string]string{v1.ApplicationLabel: application.Name},
+			},
+			Data:     runtime.RawExtension{Raw: raw},
+			Revision: latest + 1,
+		}
+		if err := ctrl.SetControllerReference(application, &current, r.Scheme); err != nil {
+			return 0, err
+		}
+		if err := r.Create(ctx, &current); err != nil {
+			return 0, err
+		}
+		revisions = append(revisions, current)
+	}
+
+	limit := v1.DefaultRevisionHistoryLimit
+	if application.Spec.RevisionHistoryLimit != nil {
+		limit = int(*application.Spec.RevisionHistoryLimit)
+	}
+	for len(revisions) > limit {
+		if err := r.Delete(ctx, &revisions[0]); client.IgnoreNotFound(err) != nil {
+			return 0, err
+		}
+		revisions = revisions[1:]
+	}
+
+	application.Status.Revisions = make([]v1.ApplicationRevision, len(revisions))
+	for i, c := range revisions {
+		var data revision
+		if err := json.Unmarshal(c.Data.Raw, &data); err != nil {
+			return 0, fmt.Errorf("reading revision %d: %w", c.Revision, err)
+		}
+		application.Status.Revisions[i] = v1.ApplicationRevision{
+			Revision:  c.Revision,
+			Name:      c.Name,
+			Templates: data.Templates,
+		}
+	}
+	return revisions[len(revisions)-1].Revision, nil
+}
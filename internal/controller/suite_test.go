@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	braidjamesparkerdevv1 "github.com/james226/braid/api/v1"
+	braidjamesparkerdevv2 "github.com/james226/braid/api/v2"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -61,6 +62,8 @@ var _ = BeforeSuite(func() {
 	var err error
 	err = braidjamesparkerdevv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = braidjamesparkerdevv2.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
 
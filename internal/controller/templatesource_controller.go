@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/gitsource"
+)
+
+// TemplateSourceLabel is set on templates read from a TemplateSource to the
+// name of the TemplateSource.
+const TemplateSourceLabel = "braid.james-parker.dev/template-source"
+
+// TemplateSourceReconciler reconciles a TemplateSource object
+type TemplateSourceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=templatesources,verbs=get;list;watch
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=templatesources/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=templatesources/finalizers,verbs=update
+// +kubebuilder:rbac:groups=braid.james-parker.dev,resources=objecttemplates;applicationtemplates,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile fetches the repository of a TemplateSource, applies the templates
+// it contains and deletes templates that were removed from it.
+func (r *TemplateSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := logf.FromContext(ctx)
+
+	var source v1.TemplateSource
+	err := r.Get(ctx, req.NamespacedName, &source)
+
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	interval := source.Spec.Interval.Duration
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	commit, files, err := gitsource.Fetch(ctx, source.Spec.URL, source.Spec.Ref)
+	if err != nil {
+		l.Error(err, "unable to fetch repository", "url", source.Spec.URL)
+		return r.fail(ctx, &source, "FetchFailed", err)
+	}
+
+	catalog, err := gitsource.Load(files, source.Spec.Path, source.Namespace)
+	if err != nil {
+		l.Error(err, "unable to read templates", "commit", commit)
+		return r.fail(ctx, &source, "InvalidTemplates", err)
+	}
+
+	var templates []client.Object
+	for _, t := range catalog.ObjectTemplates {
+		t.SetGroupVersionKind(v1.GroupVersion.WithKind("ObjectTemplate"))
+		templates = append(templates, t)
+	}
+	for _, t := range catalog.ApplicationTemplates {
+		t.SetGroupVersionKind(v1.GroupVersion.WithKind("ApplicationTemplate"))
+		templates = append(templates, t)
+	}
+
+	keep := make(map[string]bool, len(templates))
+	for _, t := range templates {
+		err = r.applyTemplate(ctx, &source, t)
+		if err != nil {
+			l.Error(err, "unable to apply template", "kind", t.GetObjectKind().GroupVersionKind().Kind, "name", t.GetName())
+			return r.fail(ctx, &source, "ApplyFailed", err)
+		}
+		keep[t.GetObjectKind().GroupVersionKind().Kind+"/"+t.GetName()] = true
+	}
+
+	err = r.pruneTemplates(ctx, &source, keep)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	source.Status.Commit = commit
+	source.Status.ObservedGeneration = source.Generation
+	message := fmt.Sprintf("Applied %d templates from %s", len(templates), commit)
+	err = r.setReady(ctx, &source, metav1.ConditionTrue, "Applied", message)
+	return ctrl.Result{RequeueAfter: interval}, err
+}
+
+func (r *TemplateSourceReconciler) applyTemplate(ctx context.Context, source *v1.TemplateSource, template client.Object) error {
+	labels := template.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[TemplateSourceLabel] = source.Name
+	template.SetLabels(labels)
+
+	err := ctrl.SetControllerReference(source, template, r.Scheme)
+	if err != nil {
+		return err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(template)
+	if err != nil {
+		return err
+	}
+	object := &unstructured.Unstructured{Object: content}
+	return r.Apply(ctx, client.ApplyConfigurationFromUnstructured(object), client.FieldOwner("braid"))
+}
+
+// pruneTemplates deletes templates created by source that are not in keep.
+func (r *TemplateSourceReconciler) pruneTemplates(ctx context.Context, source *v1.TemplateSource, keep map[string]bool) error {
+	l := logf.FromContext(ctx)
+
+	opts := []client.ListOption{client.InNamespace(source.Namespace), client.MatchingLabels{TemplateSourceLabel: source.Name}}
+
+	var objectTemplates v1.ObjectTemplateList
+	err := r.List(ctx, &objectTemplates, opts...)
+	if err != nil {
+		return err
+	}
+	var applicationTemplates v1.ApplicationTemplateList
+	err = r.List(ctx, &applicationTemplates, opts...)
+	if err != nil {
+		return err
+	}
+
+	var existing []client.Object
+	for i := range objectTemplates.Items {
+		existing = append(existing, &objectTemplates.Items[i])
+	}
+	for i := range applicationTemplates.Items {
+		existing = append(existing, &applicationTemplates.Items[i])
+	}
+
+	for _, t := range existing {
+		kind := "ObjectTemplate"
+		if _, ok := t.(*v1.ApplicationTemplate); ok {
+			kind = "ApplicationTemplate"
+		}
+		if keep[kind+"/"+t.GetName()] {
+			continue
+		}
+		owner := metav1.GetControllerOf(t)
+		if owner == nil || owner.UID != source.UID {
+			continue
+		}
+
+		l.Info("Deleting template removed from source", "kind", kind, "name", t.GetName())
+		err = client.IgnoreNotFound(r.Delete(ctx, t))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fail records err on the Ready condition and returns it so the request is
+// retried with backoff.
+func (r *TemplateSourceReconciler) fail(ctx context.Context, source *v1.TemplateSource, reason string, err error) (ctrl.Result, error) {
+	statusErr := r.setReady(ctx, source, metav1.ConditionFalse, reason, err.Error())
+	if statusErr != nil {
+		logf.FromContext(ctx).Error(statusErr, "unable to update TemplateSource status")
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *TemplateSourceReconciler) setReady(ctx context.Context, source *v1.TemplateSource, status metav1.ConditionStatus, reason, message string) error {
+	meta.SetStatusCondition(&source.Status.Conditions, metav1.Condition{
+		Type:               "Ready",
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: source.Generation,
+	})
+	return r.Status().Update(ctx, source)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemplateSourceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1.TemplateSource{}).
+		Named("templatesource").
+		Complete(r)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	braidv1 "github.com/james226/braid/api/v1"
+)
+
+var _ = Describe("TemplateSource Controller", func() {
+	Context("When reconciling a TemplateSource backed by a local repository", func() {
+		const resourceName = "git-templates"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var (
+			repoDir  string
+			worktree *git.Worktree
+		)
+
+		commit := func(files map[string]string, removed ...string) string {
+			for name, content := range files {
+				path := filepath.Join(repoDir, name)
+				Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+				Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+				_, err := worktree.Add(name)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			for _, name := range removed {
+				_, err := worktree.Remove(name)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			hash, err := worktree.Commit("update templates", &git.CommitOptions{Author: &object.Signature{
+				Name: "braid", Email: "braid@example.com", When: time.Now(),
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			return hash.String()
+		}
+
+		reconcileSource := func() {
+			controllerReconciler := &TemplateSourceReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			repoDir = GinkgoT().TempDir()
+			repo, err := git.PlainInit(repoDir, false)
+			Expect(err).NotTo(HaveOccurred())
+			worktree, err = repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+
+			By("creating the TemplateSource")
+			source := &braidv1.TemplateSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: braidv1.TemplateSourceSpec{
+					URL:  "file://" + repoDir,
+					Path: "templates",
+				},
+			}
+			Expect(k8sClient.Create(ctx, source)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the TemplateSource and its templates")
+			Expect(k8sClient.Delete(ctx, &braidv1.TemplateSource{ObjectMeta: metav1.ObjectMeta{
+				Name: resourceName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.DeleteAllOf(ctx, &braidv1.ObjectTemplate{}, client.InNamespace("default"),
+				client.MatchingLabels{TemplateSourceLabel: resourceName})).To(Succeed())
+		})
+
+		It("should apply the templates of the commit and prune removed ones", func() {
+			first := commit(map[string]string{
+				"templates/configmap.yaml": `apiVersion: braid.james-parker.dev/v1
+kind: ObjectTemplate
+metadata:
+  name: git-configmap
+spec:
+  apiVersion: v1
+  kind: ConfigMap
+`,
+				"templates/secret.yaml": `apiVersion: braid.james-parker.dev/v2
+kind: ObjectTemplate
+metadata:
+  name: git-secret
+spec:
+  apiVersion: v1
+  kind: Secret
+  source:
+    spec: "{}"
+`,
+			})
+			reconcileSource()
+
+			template := &braidv1.ObjectTemplate{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "git-configmap", Namespace: "default"}, template)).To(Succeed())
+			Expect(template.Labels).To(HaveKeyWithValue(TemplateSourceLabel, resourceName))
+			Expect(metav1.GetControllerOf(template).Name).To(Equal(resourceName))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "git-secret", Namespace: "default"}, template)).To(Succeed())
+
+			source := &braidv1.TemplateSource{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, source)).To(Succeed())
+			Expect(source.Status.Commit).To(Equal(first))
+			Expect(meta.IsStatusConditionTrue(source.Status.Conditions, "Ready")).To(BeTrue())
+
+			By("Removing a template from the repository")
+			second := commit(nil, "templates/secret.yaml")
+			reconcileSource()
+
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: "git-secret", Namespace: "default"}, template)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "git-configmap", Namespace: "default"}, template)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, source)).To(Succeed())
+			Expect(source.Status.Commit).To(Equal(second))
+		})
+	})
+})
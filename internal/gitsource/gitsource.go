@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitsource reads braid templates from Git repositories.
+package gitsource
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+// Fetch clones the repository at url into memory and checks out ref. It
+// returns the resolved commit and the checked out files.
+func Fetch(ctx context.Context, url string, ref v1.GitRef) (string, billy.Filesystem, error) {
+	files := memfs.New()
+	opts := &git.CloneOptions{URL: url, Tags: git.NoTags}
+	switch {
+	case ref.Commit != "":
+		// Any commit may be requested, so the full history is needed.
+	case ref.Tag != "":
+		opts.ReferenceName = plumbing.NewTagReferenceName(ref.Tag)
+		opts.SingleBranch = true
+		opts.Depth = 1
+	case ref.Branch != "":
+		opts.ReferenceName = plumbing.NewBranchReferenceName(ref.Branch)
+		opts.SingleBranch = true
+		opts.Depth = 1
+	default:
+		opts.SingleBranch = true
+		opts.Depth = 1
+	}
+
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), files, opts)
+	if err != nil {
+		return "", nil, fmt.Errorf("cloning %s: %w", url, err)
+	}
+
+	if ref.Commit != "" {
+		worktree, err := repo.Worktree()
+		if err != nil {
+			return "", nil, err
+		}
+		err = worktree.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(ref.Commit)})
+		if err != nil {
+			return "", nil, fmt.Errorf("checking out %s: %w", ref.Commit, err)
+		}
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", nil, err
+	}
+	return head.Hash().String(), files, nil
+}
+
+// Load reads every braid template in the YAML files below dir. Objects are
+// placed in namespace regardless of the namespace set in the files.
+func Load(files billy.Filesystem, dir, namespace string) (*render.Catalog, error) {
+	root := path.Clean("/" + dir)
+	catalog := &render.Catalog{}
+	err := util.Walk(files, root, func(file string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(path.Ext(file))
+		if info.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			return nil
+		}
+
+		f, err := files.Open(file)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+
+		if err := catalog.Load(f, namespace); err != nil {
+			return fmt.Errorf("%s: %w", strings.TrimPrefix(file, "/"), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range catalog.ObjectTemplates {
+		t.Namespace = namespace
+	}
+	for _, t := range catalog.ApplicationTemplates {
+		t.Namespace = namespace
+	}
+	catalog.Applications = nil
+	return catalog, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitsource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+const objectTemplate = `apiVersion: braid.james-parker.dev/v1
+kind: ObjectTemplate
+metadata:
+  name: %s
+  namespace: elsewhere
+spec:
+  apiVersion: v1
+  kind: ConfigMap
+`
+
+// repository creates a Git repository in a temporary directory with one
+// commit per entry in commits, each writing the given files.
+func repository(t *testing.T, commits ...map[string]string) (string, []plumbing.Hash) {
+	t.Helper()
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	g.Expect(err).NotTo(HaveOccurred())
+	worktree, err := repo.Worktree()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var hashes []plumbing.Hash
+	for i, files := range commits {
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			g.Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+			g.Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+			_, err := worktree.Add(name)
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		hash, err := worktree.Commit("commit", &git.CommitOptions{Author: &object.Signature{
+			Name: "braid", Email: "braid@example.com", When: time.Unix(int64(i), 0),
+		}})
+		g.Expect(err).NotTo(HaveOccurred())
+		hashes = append(hashes, hash)
+	}
+	return dir, hashes
+}
+
+func TestFetchAndLoad(t *testing.T) {
+	g := NewWithT(t)
+	dir, hashes := repository(t,
+		map[string]string{"templates/a.yaml": fmt.Sprintf(objectTemplate, "a"), "README.md": "# templates"},
+		map[string]string{"templates/nested/b.yml": fmt.Sprintf(objectTemplate, "b"), "other/c.yaml": fmt.Sprintf(objectTemplate, "c")},
+	)
+
+	commit, files, err := Fetch(context.Background(), "file://"+dir, v1.GitRef{})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(commit).To(Equal(hashes[1].String()))
+
+	catalog, err := Load(files, "templates", "team")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(catalog.ObjectTemplates).To(HaveLen(2))
+	g.Expect(catalog.ObjectTemplates[0].Name).To(Equal("a"))
+	g.Expect(catalog.ObjectTemplates[0].Namespace).To(Equal("team"))
+	g.Expect(catalog.ObjectTemplates[1].Name).To(Equal("b"))
+}
+
+func TestFetchRefs(t *testing.T) {
+	g := NewWithT(t)
+	dir, hashes := repository(t,
+		map[string]string{"a.yaml": fmt.Sprintf(objectTemplate, "a")},
+		map[string]string{"b.yaml": fmt.Sprintf(objectTemplate, "b")},
+	)
+	repo, err := git.PlainOpen(dir)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = repo.CreateTag("v1.0.0", hashes[0], nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/release", hashes[0]))).To(Succeed())
+
+	// A bare clone stands in for a repository hosted elsewhere.
+	bare := filepath.Join(t.TempDir(), "templates.git")
+	_, err = git.PlainClone(bare, true, &git.CloneOptions{URL: dir, Mirror: true})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for name, ref := range map[string]v1.GitRef{
+		"branch": {Branch: "release"},
+		"tag":    {Tag: "v1.0.0"},
+		"commit": {Commit: hashes[0].String(), Branch: "master"},
+	} {
+		commit, files, err := Fetch(context.Background(), bare, ref)
+		g.Expect(err).NotTo(HaveOccurred(), name)
+		g.Expect(commit).To(Equal(hashes[0].String()), name)
+
+		catalog, err := Load(files, "", "default")
+		g.Expect(err).NotTo(HaveOccurred(), name)
+		g.Expect(catalog.ObjectTemplates).To(HaveLen(1), name)
+	}
+
+	_, _, err = Fetch(context.Background(), bare, v1.GitRef{Branch: "missing"})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health assesses whether objects applied by braid have reached the
+// state their manifest asks for.
+package health
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Status is the health of an object.
+type Status string
+
+const (
+	// Healthy objects have reached the state of their manifest.
+	Healthy Status = "Healthy"
+	// Progressing objects are still working towards it.
+	Progressing Status = "Progressing"
+	// Degraded objects have failed to reach it.
+	Degraded Status = "Degraded"
+)
+
+// Result is the health of an object and a message explaining it.
+type Result struct {
+	Status  Status
+	Message string
+}
+
+// waitingReasons are reasons a container waits for that it does not recover
+// from without a change to its Pod.
+var waitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// Assess returns the health of object from its status. Kinds built into
+// Kubernetes that report progress in their own fields are assessed from
+// them; other kinds from their Ready and Stalled conditions, if they have
+// them. Objects without a status are Healthy.
+func Assess(object *unstructured.Unstructured) Result {
+	generation := object.GetGeneration()
+	observed, found, _ := unstructured.NestedInt64(object.Object, "status", "observedGeneration")
+	if found && generation > observed {
+		return Result{Progressing, fmt.Sprintf("generation %d has not been observed yet", generation)}
+	}
+
+	gvk := object.GroupVersionKind()
+	switch {
+	case gvk.Group == "apps" && gvk.Kind == "Deployment":
+		return deployment(object)
+	case gvk.Group == "apps" && gvk.Kind == "StatefulSet":
+		return statefulSet(object)
+	case gvk.Group == "apps" && gvk.Kind == "DaemonSet":
+		return daemonSet(object)
+	case gvk.Group == "apps" && gvk.Kind == "ReplicaSet":
+		return replicas(object, "readyReplicas")
+	case gvk.Group == "batch" && gvk.Kind == "Job":
+		return job(object)
+	case gvk.Group == "" && gvk.Kind == "Pod":
+		return pod(object)
+	case gvk.Group == "" && gvk.Kind == "PersistentVolumeClaim":
+		return persistentVolumeClaim(object)
+	case gvk.Group == "" && gvk.Kind == "Service":
+		return service(object)
+	}
+	return conditions(object)
+}
+
+func deployment(object *unstructured.Unstructured) Result {
+	if c := condition(object, "Progressing"); c != nil && c["reason"] == "ProgressDeadlineExceeded" {
+		return Result{Degraded, fmt.Sprintf("%v", c["message"])}
+	}
+	want := specReplicas(object)
+	updated := statusInt(object, "updatedReplicas")
+	if updated < want {
+		return Result{Progressing, fmt.Sprintf("%d of %d replicas updated", updated, want)}
+	}
+	if total := statusInt(object, "replicas"); total > updated {
+		return Result{Progressing, fmt.Sprintf("%d old replicas pending termination", total-updated)}
+	}
+	return replicas(object, "availableReplicas")
+}
+
+func statefulSet(object *unstructured.Unstructured) Result {
+	want := specReplicas(object)
+	if ready := statusInt(object, "readyReplicas"); ready < want {
+		return Result{Progressing, fmt.Sprintf("%d of %d replicas ready", ready, want)}
+	}
+	current, _, _ := unstructured.NestedString(object.Object, "status", "currentRevision")
+	update, _, _ := unstructured.NestedString(object.Object, "status", "updateRevision")
+	if update != "" && current != update {
+		updated := statusInt(object, "updatedReplicas")
+		return Result{Progressing, fmt.Sprintf("%d of %d replicas updated", updated, want)}
+	}
+	return Result{Status: Healthy}
+}
+
+func daemonSet(object *unstructured.Unstructured) Result {
+	want := statusInt(object, "desiredNumberScheduled")
+	if updated := statusInt(object, "updatedNumberScheduled"); updated < want {
+		return Result{Progressing, fmt.Sprintf("%d of %d pods updated", updated, want)}
+	}
+	if available := statusInt(object, "numberAvailable"); available < want {
+		return Result{Progressing, fmt.Sprintf("%d of %d pods available", available, want)}
+	}
+	return Result{Status: Healthy}
+}
+
+func replicas(object *unstructured.Unstructured, field string) Result {
+	want := specReplicas(object)
+	if got := statusInt(object, field); got < want {
+		return Result{Progressing, fmt.Sprintf("%d of %d replicas available", got, want)}
+	}
+	return Result{Status: Healthy}
+}
+
+func job(object *unstructured.Unstructured) Result {
+	if c := condition(object, "Failed"); c != nil && c["status"] == "True" {
+		return Result{Degraded, fmt.Sprintf("%v", c["message"])}
+	}
+	if c := condition(object, "Complete"); c != nil && c["status"] == "True" {
+		return Result{Status: Healthy}
+	}
+	return Result{Progressing, "job has not completed"}
+}
+
+func pod(object *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(object.Object, "status", "phase")
+	switch phase {
+	case "Succeeded":
+		return Result{Status: Healthy}
+	case "Failed":
+		message, _, _ := unstructured.NestedString(object.Object, "status", "message")
+		return Result{Degraded, fmt.Sprintf("pod failed: %s", message)}
+	}
+	statuses, _, _ := unstructured.NestedSlice(object.Object, "status", "containerStatuses")
+	for _, s := range statuses {
+		status, _ := s.(map[string]any)
+		reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason")
+		if waitingReasons[reason] {
+			message, _, _ := unstructured.NestedString(status, "state", "waiting", "message")
+			return Result{Degraded, fmt.Sprintf("container %v is waiting: %s %s", status["name"], reason, message)}
+		}
+	}
+	if c := condition(object, "Ready"); c != nil && c["status"] == "True" {
+		return Result{Status: Healthy}
+	}
+	return Result{Progressing, "pod is not ready"}
+}
+
+func persistentVolumeClaim(object *unstructured.Unstructured) Result {
+	phase, _, _ := unstructured.NestedString(object.Object, "status", "phase")
+	switch phase {
+	case "Bound":
+		return Result{Status: Healthy}
+	case "Lost":
+		return Result{Degraded, "claim lost its volume"}
+	}
+	return Result{Progressing, "claim is not bound"}
+}
+
+func service(object *unstructured.Unstructured) Result {
+	serviceType, _, _ := unstructured.NestedString(object.Object, "spec", "type")
+	if serviceType != "LoadBalancer" {
+		return Result{Status: Healthy}
+	}
+	ingress, _, _ := unstructured.NestedSlice(object.Object, "status", "loadBalancer", "ingress")
+	if len(ingress) == 0 {
+		return Result{Progressing, "load balancer has not been provisioned"}
+	}
+	return Result{Status: Healthy}
+}
+
+// conditions assesses kinds that follow the Ready and Stalled condition
+// conventions.
+func conditions(object *unstructured.Unstructured) Result {
+	if c := condition(object, "Stalled"); c != nil && c["status"] == "True" {
+		return Result{Degraded, fmt.Sprintf("%v", c["message"])}
+	}
+	if c := condition(object, "Ready"); c != nil && c["status"] != "True" {
+		return Result{Progressing, fmt.Sprintf("%v", c["message"])}
+	}
+	return Result{Status: Healthy}
+}
+
+// condition returns the status condition of object of the given type.
+func condition(object *unstructured.Unstructured, conditionType string) map[string]any {
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	for _, c := range conditions {
+		c, ok := c.(map[string]any)
+		if ok && c["type"] == conditionType {
+			return c
+		}
+	}
+	return nil
+}
+
+func specReplicas(object *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(object.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+func statusInt(object *unstructured.Unstructured, field string) int64 {
+	value, _, _ := unstructured.NestedInt64(object.Object, "status", field)
+	return value
+}
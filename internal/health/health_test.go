@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+func TestAssess(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		object  string
+		status  Status
+		message string
+	}{{
+		name:   "object without status",
+		object: "apiVersion: v1\nkind: ConfigMap\n",
+		status: Healthy,
+	}, {
+		name: "generation not observed",
+		object: `apiVersion: apps/v1
+kind: Deployment
+metadata: {generation: 3}
+status: {observedGeneration: 2}
+`,
+		status:  Progressing,
+		message: "generation 3 has not been observed yet",
+	}, {
+		name: "deployment rolling out",
+		object: `apiVersion: apps/v1
+kind: Deployment
+spec: {replicas: 3}
+status: {replicas: 4, updatedReplicas: 2, availableReplicas: 3}
+`,
+		status:  Progressing,
+		message: "2 of 3 replicas updated",
+	}, {
+		name: "deployment past its deadline",
+		object: `apiVersion: apps/v1
+kind: Deployment
+status:
+  conditions:
+  - {type: Progressing, status: "False", reason: ProgressDeadlineExceeded, message: ReplicaSet "web-1" has timed out progressing.}
+`,
+		status:  Degraded,
+		message: `ReplicaSet "web-1" has timed out progressing.`,
+	}, {
+		name: "deployment available",
+		object: `apiVersion: apps/v1
+kind: Deployment
+spec: {replicas: 2}
+status: {replicas: 2, updatedReplicas: 2, availableReplicas: 2}
+`,
+		status: Healthy,
+	}, {
+		name: "statefulset updating",
+		object: `apiVersion: apps/v1
+kind: StatefulSet
+spec: {replicas: 2}
+status: {readyReplicas: 2, updatedReplicas: 1, currentRevision: a, updateRevision: b}
+`,
+		status:  Progressing,
+		message: "1 of 2 replicas updated",
+	}, {
+		name: "daemonset available",
+		object: `apiVersion: apps/v1
+kind: DaemonSet
+status: {desiredNumberScheduled: 3, updatedNumberScheduled: 3, numberAvailable: 3}
+`,
+		status: Healthy,
+	}, {
+		name: "failed job",
+		object: `apiVersion: batch/v1
+kind: Job
+status:
+  conditions:
+  - {type: Failed, status: "True", message: Job has reached the specified backoff limit}
+`,
+		status:  Degraded,
+		message: "Job has reached the specified backoff limit",
+	}, {
+		name: "crashing pod",
+		object: `apiVersion: v1
+kind: Pod
+status:
+  phase: Running
+  containerStatuses:
+  - name: web
+    state: {waiting: {reason: CrashLoopBackOff, message: back-off restarting}}
+`,
+		status:  Degraded,
+		message: "container web is waiting: CrashLoopBackOff back-off restarting",
+	}, {
+		name:    "pending claim",
+		object:  "apiVersion: v1\nkind: PersistentVolumeClaim\nstatus: {phase: Pending}\n",
+		status:  Progressing,
+		message: "claim is not bound",
+	}, {
+		name:    "load balancer without ingress",
+		object:  "apiVersion: v1\nkind: Service\nspec: {type: LoadBalancer}\n",
+		status:  Progressing,
+		message: "load balancer has not been provisioned",
+	}, {
+		name: "custom resource not ready",
+		object: `apiVersion: example.com/v1
+kind: Database
+status:
+  conditions:
+  - {type: Ready, status: "False", message: provisioning}
+`,
+		status:  Progressing,
+		message: "provisioning",
+	}, {
+		name: "custom resource stalled",
+		object: `apiVersion: example.com/v1
+kind: Database
+status:
+  conditions:
+  - {type: Stalled, status: "True", message: quota exceeded}
+`,
+		status:  Degraded,
+		message: "quota exceeded",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			object := &unstructured.Unstructured{}
+			g.Expect(yaml.Unmarshal([]byte(tc.object), &object.Object)).To(Succeed())
+			g.Expect(Assess(object)).To(Equal(Result{tc.status, tc.message}))
+		})
+	}
+}
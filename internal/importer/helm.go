@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer converts existing manifests into braid templates.
+package importer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Renderer renders a chart with the given values into a stream of YAML
+// documents.
+type Renderer interface {
+	Render(ctx context.Context, values map[string]any) ([]byte, error)
+}
+
+// Helm renders a local chart by running `helm template`.
+type Helm struct {
+	// Binary is the helm executable, "helm" if empty.
+	Binary    string
+	Chart     string
+	Release   string
+	Namespace string
+}
+
+// Render implements Renderer.
+func (h Helm) Render(ctx context.Context, values map[string]any) ([]byte, error) {
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp("", "braid-values-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	binary := h.Binary
+	if binary == "" {
+		binary = "helm"
+	}
+	args := []string{"template", h.Release, h.Chart, "--values", f.Name()}
+	if h.Namespace != "" {
+		args = append(args, "--namespace", h.Namespace)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s template: %w: %s", binary, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
+
+// LoadValues reads the default values of a chart and merges the given
+// values files over them, later files taking precedence, as helm does.
+func LoadValues(chart string, files ...string) (map[string]any, error) {
+	values := map[string]any{}
+	paths := append([]string{filepath.Join(chart, "values.yaml")}, files...)
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if i == 0 && errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var file map[string]any
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		merge(values, file)
+	}
+	return values, nil
+}
+
+func merge(dst, src map[string]any) {
+	for k, v := range src {
+		from, ok := v.(map[string]any)
+		to, isMap := dst[k].(map[string]any)
+		if ok && isMap {
+			merge(to, from)
+			continue
+		}
+		dst[k] = v
+	}
+}
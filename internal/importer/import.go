@@ -0,0 +1,306 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// Result is the outcome of an import.
+type Result struct {
+	ApplicationTemplate *v1.ApplicationTemplate
+	ObjectTemplates     []*v1.ObjectTemplate
+
+	// Warnings describes anything that could not be carried over.
+	Warnings []string
+}
+
+// variable is a value that was traced to the places it appears in the
+// rendered output.
+type variable struct {
+	name     string
+	path     []string
+	original string
+
+	// sentinel replaces the value while tracing; text is how it appears in
+	// rendered output.
+	sentinel any
+	text     string
+}
+
+// Import renders a chart and turns each rendered resource into an
+// ObjectTemplate holding its complete manifest, plus an ApplicationTemplate
+// named name listing them.
+//
+// A value becomes a variable when replacing it with a unique placeholder
+// changes the rendered output only where the placeholder appears. Values
+// that drive conditionals, loops or functions other than plain substitution
+// stay literal in the templates.
+func Import(ctx context.Context, r Renderer, name string, values map[string]any) (*Result, error) {
+	baseline, err := r.Render(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	var traced []variable
+	for _, v := range candidates(values) {
+		if _, ok, err := substitute(ctx, r, values, baseline, []variable{v}); err != nil {
+			return nil, err
+		} else if ok {
+			traced = append(traced, v)
+		}
+	}
+
+	rendered := baseline
+	if len(traced) > 0 {
+		out, ok, err := substitute(ctx, r, values, baseline, traced)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rendered = out
+		} else {
+			traced = nil
+			result.Warnings = append(result.Warnings,
+				"values could not be substituted together; templates contain the rendered values")
+		}
+	}
+
+	docs, err := documents(rendered)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ApplicationTemplate = &v1.ApplicationTemplate{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "ApplicationTemplate"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	for _, doc := range docs {
+		kind, _ := doc["kind"].(string)
+		metadata, _ := doc["metadata"].(map[string]any)
+		resource, _ := metadata["name"].(string)
+
+		// braid places every rendered object in the Application's
+		// namespace.
+		delete(metadata, "namespace")
+
+		used := map[string]variable{}
+		manifest := templatize(doc, traced, used)
+		data, err := yaml.Marshal(manifest)
+		if err != nil {
+			return nil, err
+		}
+		text := string(data)
+		for _, v := range used {
+			text = strings.ReplaceAll(text, numberMarker(v), "{{ ."+v.name+" }}")
+		}
+
+		object := &v1.ObjectTemplate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "ObjectTemplate"},
+			ObjectMeta: metav1.ObjectMeta{Name: dnsName(kind + "-" + resource)},
+			Spec:       v1.ObjectTemplateSpec{Manifests: text},
+		}
+		ref := v1.ApplicationObject{Template: object.Name}
+		for name, v := range used {
+			object.Spec.Variables = append(object.Spec.Variables, name)
+			if ref.Variables == nil {
+				ref.Variables = map[string]string{}
+			}
+			ref.Variables[name] = v.original
+		}
+		sort.Strings(object.Spec.Variables)
+
+		result.ObjectTemplates = append(result.ObjectTemplates, object)
+		result.ApplicationTemplate.Spec.Objects = append(result.ApplicationTemplate.Spec.Objects, ref)
+	}
+	return result, nil
+}
+
+// candidates returns every string and number in values with the
+// placeholder used to trace it.
+func candidates(values map[string]any) []variable {
+	var vars []variable
+	names := map[string]bool{}
+	var walk func(node map[string]any, path []string)
+	walk = func(node map[string]any, path []string) {
+		keys := make([]string, 0, len(node))
+		for k := range node {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			p := append(append([]string{}, path...), k)
+			v := variable{path: p, name: variableName(p, names)}
+			n := len(vars)
+			switch value := node[k].(type) {
+			case map[string]any:
+				walk(value, p)
+				continue
+			case string:
+				v.original = value
+				v.text = fmt.Sprintf("braid%04dvalue", n)
+				v.sentinel = v.text
+			case float64:
+				// Templates print numbers with %v, which switches to
+				// exponent form from a million, so the placeholder stays
+				// below that.
+				v.original = fmt.Sprint(value)
+				v.sentinel = float64(900000 + n)
+				v.text = strconv.Itoa(900000 + n)
+			default:
+				continue
+			}
+			names[v.name] = true
+			vars = append(vars, v)
+		}
+	}
+	walk(values, nil)
+	return vars
+}
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// variableName turns a values path into a template field name that is not
+// already taken.
+func variableName(path []string, taken map[string]bool) string {
+	name := invalidNameChars.ReplaceAllString(strings.Join(path, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	return unique
+}
+
+// substitute renders with vars replaced by their placeholders. It reports
+// whether every placeholder appears in the output and putting the original
+// values back reproduces baseline exactly.
+func substitute(ctx context.Context, r Renderer, values map[string]any, baseline []byte, vars []variable) ([]byte, bool, error) {
+	modified := runtime.DeepCopyJSON(values)
+	for _, v := range vars {
+		node := modified
+		for _, k := range v.path[:len(v.path)-1] {
+			node = node[k].(map[string]any)
+		}
+		node[v.path[len(v.path)-1]] = v.sentinel
+	}
+
+	out, err := r.Render(ctx, modified)
+	if err != nil {
+		// The chart rejected the placeholder, so the value is not a plain
+		// substitution.
+		return nil, false, nil //nolint:nilerr
+	}
+
+	restored := out
+	for _, v := range vars {
+		if !bytes.Contains(out, []byte(v.text)) {
+			return nil, false, nil
+		}
+		restored = bytes.ReplaceAll(restored, []byte(v.text), []byte(v.original))
+	}
+	return out, bytes.Equal(restored, baseline), nil
+}
+
+// templatize replaces placeholders in node with template actions, recording
+// the variables it used. Numbers are replaced with a marker that is swapped
+// for an unquoted action once the spec has been serialized.
+func templatize(node any, vars []variable, used map[string]variable) any {
+	switch n := node.(type) {
+	case map[string]any:
+		for k, v := range n {
+			n[k] = templatize(v, vars, used)
+		}
+	case []any:
+		for i, v := range n {
+			n[i] = templatize(v, vars, used)
+		}
+	case string:
+		for _, v := range vars {
+			if strings.Contains(n, v.text) {
+				n = strings.ReplaceAll(n, v.text, "{{ ."+v.name+" }}")
+				used[v.name] = v
+			}
+		}
+		return n
+	case float64:
+		for _, v := range vars {
+			if f, ok := v.sentinel.(float64); ok && f == n {
+				used[v.name] = v
+				return numberMarker(v)
+			}
+		}
+	}
+	return node
+}
+
+func numberMarker(v variable) string {
+	return "braidnumber" + v.text + "marker"
+}
+
+// documents splits a YAML stream into objects, skipping empty documents.
+func documents(stream []byte) ([]map[string]any, error) {
+	var docs []map[string]any
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(stream)))
+	for {
+		data, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var doc map[string]any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		if len(doc) > 0 {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+var invalidDNSChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// dnsName turns s into a valid object name.
+func dnsName(s string) string {
+	s = strings.Trim(invalidDNSChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(s) > 253 {
+		s = strings.TrimRight(s[:253], "-")
+	}
+	return s
+}
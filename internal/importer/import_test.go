@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	. "github.com/onsi/gomega"
+)
+
+type rendererFunc func(ctx context.Context, values map[string]any) ([]byte, error)
+
+func (f rendererFunc) Render(ctx context.Context, values map[string]any) ([]byte, error) {
+	return f(ctx, values)
+}
+
+// goTemplate renders text with values as .Values, as a chart template would.
+func goTemplate(text string) Renderer {
+	tmpl := template.Must(template.New("chart").Parse(text))
+	return rendererFunc(func(_ context.Context, values map[string]any) ([]byte, error) {
+		var out bytes.Buffer
+		err := tmpl.Execute(&out, map[string]any{"Values": values})
+		return out.Bytes(), err
+	})
+}
+
+func TestImportTracesSubstitutedValues(t *testing.T) {
+	g := NewWithT(t)
+	r := goTemplate(`apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: {{ if eq .Values.type "NodePort" }}NodePort{{ else }}ClusterIP{{ end }}
+  ports:
+    - name: {{ .Values.name }}-http
+      port: {{ .Values.port }}
+`)
+
+	result, err := Import(context.Background(), r, "web", map[string]any{
+		"name":   "web",
+		"port":   float64(8080),
+		"type":   "ClusterIP",
+		"unused": "value",
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Warnings).To(BeEmpty())
+	g.Expect(result.ObjectTemplates).To(HaveLen(1))
+
+	object := result.ObjectTemplates[0]
+	g.Expect(object.Name).To(Equal("service-web"))
+	g.Expect(object.Spec.Variables).To(Equal([]string{"name", "port"}))
+	g.Expect(object.Spec.Manifests).To(Equal(`apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - name: '{{ .name }}-http'
+    port: {{ .port }}
+  type: ClusterIP
+`))
+	g.Expect(result.ApplicationTemplate.Spec.Objects[0].Variables).To(Equal(map[string]string{
+		"name": "web",
+		"port": "8080",
+	}))
+}
+
+func TestImportKeepsMetadataAndObjectsWithoutSpec(t *testing.T) {
+	g := NewWithT(t)
+	r := goTemplate(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: chart-namespace
+  labels:
+    app.kubernetes.io/name: {{ .Values.name }}
+data:
+  key: {{ .Values.key }}
+`)
+
+	result, err := Import(context.Background(), r, "web", map[string]any{"key": "value", "name": "web"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Warnings).To(BeEmpty())
+	g.Expect(result.ObjectTemplates).To(HaveLen(1))
+	g.Expect(result.ObjectTemplates[0].Name).To(Equal("configmap-config"))
+	g.Expect(result.ObjectTemplates[0].Spec.Manifests).To(Equal(`apiVersion: v1
+data:
+  key: '{{ .key }}'
+kind: ConfigMap
+metadata:
+  labels:
+    app.kubernetes.io/name: '{{ .name }}'
+  name: config
+`))
+}
+
+func TestLoadValuesMergesFiles(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "values.yaml"),
+		[]byte("image:\n  repository: nginx\n  tag: \"1.26\"\nreplicas: 1\n"), 0o644)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "prod.yaml"),
+		[]byte("image:\n  tag: \"1.27\"\n"), 0o644)).To(Succeed())
+
+	values, err := LoadValues(dir, filepath.Join(dir, "prod.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(values).To(Equal(map[string]any{
+		"image":    map[string]any{"repository": "nginx", "tag": "1.27"},
+		"replicas": float64(1),
+	}))
+}
+
+func TestVariableName(t *testing.T) {
+	g := NewWithT(t)
+	taken := map[string]bool{"image_tag": true}
+
+	g.Expect(variableName([]string{"image", "pull-policy"}, taken)).To(Equal("image_pull_policy"))
+	g.Expect(variableName([]string{"image", "tag"}, taken)).To(Equal("image_tag_2"))
+	g.Expect(variableName([]string{"3scale"}, taken)).To(Equal("_3scale"))
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint checks braid templates for mistakes that would only surface
+// when an Application is reconciled.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+// Severity of a finding.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule describes a single check.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+}
+
+var (
+	RuleTemplateParse = Rule{"template-parse", SeverityError,
+		"ObjectTemplate spec must be a valid template for its engine."}
+	RuleUndeclaredVariable = Rule{"undeclared-variable", SeverityError,
+		"Variables used by an ObjectTemplate must be declared in spec.variables."}
+	RuleUnusedVariable = Rule{"unused-variable", SeverityWarning,
+		"Variables declared in spec.variables should be used by the template."}
+	RuleUnknownAPIVersion = Rule{"unknown-api-version", SeverityError,
+		"ObjectTemplates must render an apiVersion and kind known to the schema."}
+	RuleMissingObjectTemplate = Rule{"missing-object-template", SeverityError,
+		"ApplicationTemplates must only reference existing ObjectTemplates."}
+)
+
+// Rules lists every check in the order they are reported.
+var Rules = []Rule{
+	RuleTemplateParse,
+	RuleUndeclaredVariable,
+	RuleUnusedVariable,
+	RuleUnknownAPIVersion,
+	RuleMissingObjectTemplate,
+}
+
+// Finding is a single problem found in a template.
+type Finding struct {
+	Rule      string   `json:"rule"`
+	Severity  Severity `json:"severity"`
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	File      string   `json:"file,omitempty"`
+	Message   string   `json:"message"`
+}
+
+// Options configures Lint.
+type Options struct {
+	// Schema lists the API versions and kinds templates may render. The API
+	// version check is skipped when it is nil.
+	Schema *Schema
+
+	// Source returns the file an object was read from, if known.
+	Source func(obj client.Object) string
+}
+
+// Lint checks every template in the catalog.
+func Lint(ctx context.Context, catalog *render.Catalog, opts Options) []Finding {
+	l := linter{opts: opts}
+	for _, t := range catalog.ObjectTemplates {
+		l.objectTemplate(t)
+	}
+	for _, t := range catalog.ApplicationTemplates {
+		l.applicationTemplate(ctx, catalog, t)
+	}
+	return l.findings
+}
+
+// HasErrors reports whether any finding has error severity.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+type linter struct {
+	opts     Options
+	findings []Finding
+}
+
+func (l *linter) report(rule Rule, obj client.Object, kind string, format string, args ...any) {
+	f := Finding{
+		Rule:      rule.ID,
+		Severity:  rule.Severity,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Message:   fmt.Sprintf(format, args...),
+	}
+	if l.opts.Source != nil {
+		f.File = l.opts.Source(obj)
+	}
+	l.findings = append(l.findings, f)
+}
+
+func (l *linter) objectTemplate(t *v1.ObjectTemplate) {
+	const kind = "ObjectTemplate"
+
+	if l.opts.Schema != nil && t.Spec.Manifests == "" {
+		l.apiVersion(t, t.Spec.ApiVersion, t.Spec.Kind)
+	}
+
+	body := t.Spec.Spec
+	if t.Spec.Manifests != "" {
+		body = t.Spec.Manifests
+	}
+	engine, err := render.EngineFor(t.Spec.Engine)
+	if err != nil {
+		l.report(RuleTemplateParse, t, kind, "%v", err)
+		return
+	}
+	used, err := engine.Variables(body)
+	if err != nil {
+		l.report(RuleTemplateParse, t, kind, "%v", err)
+		return
+	}
+
+	if l.opts.Schema != nil && t.Spec.Manifests != "" {
+		// Manifests are checked as rendered with placeholder values, so
+		// kinds chosen by a variable are not checked.
+		if objects, err := render.Manifests(&v1.Application{}, t, placeholders(t, used)); err == nil {
+			for _, o := range objects {
+				if strings.Contains(o.GetAPIVersion()+o.GetKind(), placeholder) {
+					continue
+				}
+				l.apiVersion(t, o.GetAPIVersion(), o.GetKind())
+			}
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, v := range t.Spec.Variables {
+		declared[v] = true
+	}
+	referenced := map[string]bool{}
+	for _, v := range used {
+		referenced[v] = true
+		if !declared[v] {
+			l.report(RuleUndeclaredVariable, t, kind, "variable %q is used but not declared", v)
+		}
+	}
+	unused := []string{}
+	for v := range declared {
+		if !referenced[v] {
+			unused = append(unused, v)
+		}
+	}
+	sort.Strings(unused)
+	for _, v := range unused {
+		l.report(RuleUnusedVariable, t, kind, "variable %q is declared but not used", v)
+	}
+}
+
+func (l *linter) apiVersion(t *v1.ObjectTemplate, apiVersion, kind string) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	switch {
+	case err != nil:
+		l.report(RuleUnknownAPIVersion, t, "ObjectTemplate", "invalid apiVersion %q: %v", apiVersion, err)
+	case !l.opts.Schema.HasGroupVersion(gv):
+		l.report(RuleUnknownAPIVersion, t, "ObjectTemplate", "unknown apiVersion %q", apiVersion)
+	case !l.opts.Schema.HasKind(gv.WithKind(kind)):
+		l.report(RuleUnknownAPIVersion, t, "ObjectTemplate", "kind %q is not served by %q", kind, apiVersion)
+	}
+}
+
+func (l *linter) applicationTemplate(ctx context.Context, catalog *render.Catalog, t *v1.ApplicationTemplate) {
+	for i, o := range t.Spec.Objects {
+		_, err := catalog.ObjectTemplate(ctx, t.Namespace, o.Template)
+		if apierrors.IsNotFound(err) {
+			l.report(RuleMissingObjectTemplate, t, "ApplicationTemplate",
+				"objects[%d] references missing ObjectTemplate %q", i, o.Template)
+		}
+	}
+}
+
+// placeholder is the value given to string variables when rendering a
+// template for linting.
+const placeholder = "braid-lint-placeholder"
+
+// placeholders returns a value for every variable t uses or declares that
+// satisfies the type it is declared with.
+func placeholders(t *v1.ObjectTemplate, used []string) map[string]string {
+	values := map[string]string{}
+	for _, name := range append(used, t.Spec.Variables...) {
+		values[name] = placeholder
+	}
+	for _, d := range t.Spec.VariableDefinitions {
+		switch d.Type {
+		case v1.VariableTypeInteger, v1.VariableTypeNumber:
+			values[d.Name] = "0"
+		case v1.VariableTypeBoolean:
+			values[d.Name] = "false"
+		case v1.VariableTypeObject:
+			values[d.Name] = "{}"
+		case v1.VariableTypeArray:
+			values[d.Name] = "[]"
+		default:
+			values[d.Name] = placeholder
+		}
+	}
+	return values
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/render"
+)
+
+func objectTemplate(name, apiVersion, kind, spec string, variables ...string) *v1.ObjectTemplate {
+	return &v1.ObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       v1.ObjectTemplateSpec{ApiVersion: apiVersion, Kind: kind, Spec: spec, Variables: variables},
+	}
+}
+
+func rules(findings []Finding) []string {
+	ids := []string{}
+	for _, f := range findings {
+		ids = append(ids, f.Rule)
+	}
+	return ids
+}
+
+func TestLintVariables(t *testing.T) {
+	g := NewWithT(t)
+	catalog := &render.Catalog{ObjectTemplates: []*v1.ObjectTemplate{
+		objectTemplate("ok", "v1", "ConfigMap", "data:\n  a: {{ .a }}\n  b: {{ $.b }}\n", "a", "b"),
+		objectTemplate("bad", "v1", "ConfigMap", "data:\n  a: {{ .a }}\n", "b"),
+	}}
+
+	findings := Lint(context.Background(), catalog, Options{})
+	g.Expect(rules(findings)).To(Equal([]string{RuleUndeclaredVariable.ID, RuleUnusedVariable.ID}))
+	g.Expect(findings[0].Name).To(Equal("bad"))
+	g.Expect(HasErrors(findings)).To(BeTrue())
+}
+
+func TestLintAPIVersions(t *testing.T) {
+	g := NewWithT(t)
+	catalog := &render.Catalog{ObjectTemplates: []*v1.ObjectTemplate{
+		objectTemplate("known", "apps/v1", "Deployment", ""),
+		objectTemplate("version", "apps/v2", "Deployment", ""),
+		objectTemplate("kind", "apps/v1", "Widget", ""),
+		objectTemplate("invalid", "a/b/c", "Widget", ""),
+	}}
+
+	findings := Lint(context.Background(), catalog, Options{Schema: BuiltinSchema()})
+	g.Expect(rules(findings)).To(HaveEach(RuleUnknownAPIVersion.ID))
+	g.Expect(findings).To(HaveLen(3))
+	g.Expect(findings[1].Message).To(Equal(`kind "Widget" is not served by "apps/v1"`))
+}
+
+func TestLoadOpenAPIv2(t *testing.T) {
+	g := NewWithT(t)
+	doc := `{"swagger": "2.0", "definitions": {"dev.example.v1.Widget": {
+		"x-kubernetes-group-version-kind": [{"group": "example.dev", "version": "v1", "kind": "Widget"}]}}}`
+
+	s := NewSchema()
+	g.Expect(s.LoadOpenAPI(strings.NewReader(doc))).To(Succeed())
+	g.Expect(s.HasKind(schema.GroupVersionKind{Group: "example.dev", Version: "v1", Kind: "Widget"})).To(BeTrue())
+	g.Expect(s.HasGroupVersion(schema.GroupVersion{Group: "apps", Version: "v1"})).To(BeFalse())
+}
+
+func TestLintWarningsAreNotErrors(t *testing.T) {
+	g := NewWithT(t)
+	catalog := &render.Catalog{ObjectTemplates: []*v1.ObjectTemplate{
+		objectTemplate("unused", "v1", "ConfigMap", "data: {}\n", "a"),
+	}}
+
+	findings := Lint(context.Background(), catalog, Options{})
+	g.Expect(rules(findings)).To(Equal([]string{RuleUnusedVariable.ID}))
+	g.Expect(HasErrors(findings)).To(BeFalse())
+}
+
+func TestLintManifests(t *testing.T) {
+	g := NewWithT(t)
+	tmpl := objectTemplate("multi", "", "", "")
+	tmpl.Spec.Manifests = "apiVersion: v1\nkind: ConfigMap\ndata:\n  a: {{ .a }}\n---\napiVersion: apps/v9\nkind: Deployment\n"
+
+	findings := Lint(context.Background(), &render.Catalog{ObjectTemplates: []*v1.ObjectTemplate{tmpl}},
+		Options{Schema: BuiltinSchema()})
+	g.Expect(rules(findings)).To(Equal([]string{RuleUnknownAPIVersion.ID, RuleUndeclaredVariable.ID}))
+	g.Expect(findings[0].Message).To(Equal(`unknown apiVersion "apps/v9"`))
+}
+
+func TestLintSubstitutionEngine(t *testing.T) {
+	g := NewWithT(t)
+	tmpl := objectTemplate("subst", "v1", "Service", "ports:\n  - port: ${port}\n    name: ${name}\n", "port")
+	tmpl.Spec.Engine = v1.SubstitutionEngine
+
+	findings := Lint(context.Background(), &render.Catalog{ObjectTemplates: []*v1.ObjectTemplate{tmpl}}, Options{})
+	g.Expect(rules(findings)).To(Equal([]string{RuleUndeclaredVariable.ID}))
+	g.Expect(findings[0].Message).To(Equal(`variable "name" is used but not declared`))
+}
+
+func TestLintSubstitutionManifestsWithVariables(t *testing.T) {
+	g := NewWithT(t)
+	tmpl := objectTemplate("subst-multi", "", "", "", "replicas", "kind")
+	tmpl.Spec.Engine = v1.SubstitutionEngine
+	tmpl.Spec.Manifests = "apiVersion: apps/v9\nkind: Deployment\nspec:\n  replicas: ${replicas}\n" +
+		"---\napiVersion: v1\nkind: ${kind}\n"
+	tmpl.Spec.VariableDefinitions = []v1.VariableDefinition{{Name: "replicas", Type: v1.VariableTypeInteger}}
+
+	findings := Lint(context.Background(), &render.Catalog{ObjectTemplates: []*v1.ObjectTemplate{tmpl}},
+		Options{Schema: BuiltinSchema()})
+	g.Expect(rules(findings)).To(Equal([]string{RuleUnknownAPIVersion.ID}))
+	g.Expect(findings[0].Message).To(Equal(`unknown apiVersion "apps/v9"`))
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"encoding/json"
+	"io"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// Schema is the set of API versions and kinds templates may render.
+type Schema struct {
+	kinds map[schema.GroupVersionKind]struct{}
+}
+
+// NewSchema returns an empty schema.
+func NewSchema() *Schema {
+	return &Schema{kinds: map[schema.GroupVersionKind]struct{}{}}
+}
+
+// BuiltinSchema returns the built-in Kubernetes API types bundled with the
+// client libraries braid is compiled against.
+func BuiltinSchema() *Schema {
+	s := NewSchema()
+	for gvk := range clientgoscheme.Scheme.AllKnownTypes() {
+		if gvk.Version == "__internal" {
+			continue
+		}
+		s.Add(gvk)
+	}
+	return s
+}
+
+// LoadOpenAPI reads an OpenAPI v2 or v3 document, such as the output of
+// `kubectl get --raw /openapi/v2`, and adds every kind it declares through
+// the x-kubernetes-group-version-kind extension.
+func (s *Schema) LoadOpenAPI(r io.Reader) error {
+	var doc any
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return err
+	}
+	s.collect(doc)
+	return nil
+}
+
+func (s *Schema) collect(node any) {
+	switch n := node.(type) {
+	case map[string]any:
+		if gvks, ok := n["x-kubernetes-group-version-kind"].([]any); ok {
+			for _, gvk := range gvks {
+				if m, ok := gvk.(map[string]any); ok {
+					group, _ := m["group"].(string)
+					version, _ := m["version"].(string)
+					kind, _ := m["kind"].(string)
+					s.Add(schema.GroupVersionKind{Group: group, Version: version, Kind: kind})
+				}
+			}
+		}
+		for _, v := range n {
+			s.collect(v)
+		}
+	case []any:
+		for _, v := range n {
+			s.collect(v)
+		}
+	}
+}
+
+// Add registers a kind with the schema.
+func (s *Schema) Add(gvk schema.GroupVersionKind) {
+	s.kinds[gvk] = struct{}{}
+}
+
+// HasGroupVersion reports whether any kind is known for gv.
+func (s *Schema) HasGroupVersion(gv schema.GroupVersion) bool {
+	for gvk := range s.kinds {
+		if gvk.GroupVersion() == gv {
+			return true
+		}
+	}
+	return false
+}
+
+// HasKind reports whether gvk is known.
+func (s *Schema) HasKind(gvk schema.GroupVersionKind) bool {
+	_, ok := s.kinds[gvk]
+	return ok
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the Prometheus metrics braid exposes on the
+// controller-runtime metrics endpoint.
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+var (
+	// TemplateCacheHits counts ObjectTemplate bodies found parsed in the
+	// template cache.
+	TemplateCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "braid_template_cache_hits_total",
+		Help: "Number of ObjectTemplate bodies found parsed in the template cache.",
+	})
+
+	// TemplateCacheMisses counts ObjectTemplate bodies parsed because they
+	// were not in the template cache.
+	TemplateCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "braid_template_cache_misses_total",
+		Help: "Number of ObjectTemplate bodies parsed because they were not in the template cache.",
+	})
+
+	// RenderDuration observes how long rendering each ObjectTemplate takes.
+	RenderDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "braid_render_duration_seconds",
+		Help:    "Time taken to render an ObjectTemplate for an Application.",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"namespace", "template"})
+
+	// RenderFailures counts ObjectTemplates that could not be rendered.
+	RenderFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "braid_render_failures_total",
+		Help: "Number of times an ObjectTemplate could not be rendered for an Application.",
+	}, []string{"namespace", "template"})
+
+	// ApplyDuration observes how long writing each rendered object takes.
+	ApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "braid_apply_duration_seconds",
+		Help:    "Time taken to write a rendered object to the cluster.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"group", "version", "kind"})
+
+	// ApplyFailures counts rendered objects that could not be written.
+	ApplyFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "braid_apply_failures_total",
+		Help: "Number of times a rendered object could not be written to the cluster.",
+	}, []string{"group", "version", "kind"})
+
+	// DriftCorrections counts objects changed outside braid that braid wrote
+	// back to their rendered state.
+	DriftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "braid_drift_corrections_total",
+		Help: "Number of objects changed outside braid that were written back to their rendered state.",
+	}, []string{"group", "version", "kind"})
+
+	// PrunedObjects counts objects released because they are no longer
+	// rendered or their Application was deleted.
+	PrunedObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "braid_pruned_objects_total",
+		Help: "Number of objects deleted or orphaned because they are no longer rendered or their Application was deleted.",
+	}, []string{"policy"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		TemplateCacheHits,
+		TemplateCacheMisses,
+		RenderDuration,
+		RenderFailures,
+		ApplyDuration,
+		ApplyFailures,
+		DriftCorrections,
+		PrunedObjects,
+	)
+}
+
+// GVK returns the label values of the group, version and kind metrics for
+// gvk.
+func GVK(gvk schema.GroupVersionKind) []string {
+	return []string{gvk.Group, gvk.Version, gvk.Kind}
+}
+
+var (
+	applicationsDesc = prometheus.NewDesc("braid_applications",
+		"Number of Applications by ApplicationTemplate and status of their Ready condition.",
+		[]string{"namespace", "template", "ready"}, nil)
+	managedObjectsDesc = prometheus.NewDesc("braid_managed_objects",
+		"Number of objects in the inventory of an Application.",
+		[]string{"namespace", "application"}, nil)
+)
+
+// ApplicationCollector reports the Applications read through a client when
+// metrics are scraped, so deleted Applications leave no stale series.
+type ApplicationCollector struct {
+	Reader client.Reader
+}
+
+// Describe implements prometheus.Collector.
+func (c ApplicationCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- applicationsDesc
+	ch <- managedObjectsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c ApplicationCollector) Collect(ch chan<- prometheus.Metric) {
+	var applications v1.ApplicationList
+	err := c.Reader.List(context.Background(), &applications)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(applicationsDesc, err)
+		return
+	}
+
+	type key struct{ namespace, template, ready string }
+	counts := map[key]int{}
+	for _, application := range applications.Items {
+		ready := string(metav1.ConditionUnknown)
+		if condition := meta.FindStatusCondition(application.Status.Conditions, v1.ReadyCondition); condition != nil {
+			ready = string(condition.Status)
+		}
+		counts[key{application.Namespace, application.Spec.Template, ready}]++
+
+		ch <- prometheus.MustNewConstMetric(managedObjectsDesc, prometheus.GaugeValue,
+			float64(len(application.Status.Inventory)), application.Namespace, application.Name)
+	}
+	for k, n := range counts {
+		ch <- prometheus.MustNewConstMetric(applicationsDesc, prometheus.GaugeValue, float64(n), k.namespace, k.template, k.ready)
+	}
+}
+
+// RegisterApplications registers an ApplicationCollector reading through
+// reader.
+func RegisterApplications(reader client.Reader) error {
+	return metrics.Registry.Register(ApplicationCollector{Reader: reader})
+}
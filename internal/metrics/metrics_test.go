@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+func TestApplicationCollector(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(v1.AddToScheme(scheme)).To(Succeed())
+
+	application := func(name string, ready metav1.ConditionStatus, objects int) *v1.Application {
+		a := &v1.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1.ApplicationSpec{Template: "web"},
+		}
+		if ready != "" {
+			a.Status.Conditions = []metav1.Condition{{Type: v1.ReadyCondition, Status: ready}}
+		}
+		for range objects {
+			a.Status.Inventory = append(a.Status.Inventory, v1.ManagedObject{})
+		}
+		return a
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		application("a", metav1.ConditionTrue, 2),
+		application("b", metav1.ConditionTrue, 1),
+		application("c", "", 0),
+	).Build()
+
+	g.Expect(testutil.CollectAndCompare(ApplicationCollector{Reader: c}, strings.NewReader(`
+# HELP braid_applications Number of Applications by ApplicationTemplate and status of their Ready condition.
+# TYPE braid_applications gauge
+braid_applications{namespace="default",ready="True",template="web"} 2
+braid_applications{namespace="default",ready="Unknown",template="web"} 1
+# HELP braid_managed_objects Number of objects in the inventory of an Application.
+# TYPE braid_managed_objects gauge
+braid_managed_objects{application="a",namespace="default"} 2
+braid_managed_objects{application="b",namespace="default"} 1
+braid_managed_objects{application="c",namespace="default"} 0
+`))).To(Succeed())
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/lru"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/metrics"
+)
+
+// DefaultTemplateCacheSize is the number of ObjectTemplates whose parsed
+// body Templates keeps.
+const DefaultTemplateCacheSize = 512
+
+// Templates caches the parsed bodies of the ObjectTemplates rendered by
+// Template and Manifests.
+var Templates = NewTemplateCache(DefaultTemplateCacheSize)
+
+// TemplateCache is a concurrency-safe cache of parsed ObjectTemplate bodies.
+// Entries are keyed by the UID of the ObjectTemplate and only used while its
+// resourceVersion is unchanged. The least recently used entry is evicted once
+// the cache is full.
+type TemplateCache struct {
+	lru *lru.Cache
+}
+
+type cachedTemplate struct {
+	resourceVersion string
+	parsed          Parsed
+}
+
+// NewTemplateCache returns a TemplateCache holding at most size templates.
+func NewTemplateCache(size int) *TemplateCache {
+	return &TemplateCache{lru: lru.New(size)}
+}
+
+// Parse returns body of tmpl parsed by its engine. Templates that have not
+// been stored by the API server, such as those read from files, are parsed
+// on every call.
+func (c *TemplateCache) Parse(tmpl *v1.ObjectTemplate, body string) (Parsed, error) {
+	engine, err := EngineFor(tmpl.Spec.Engine)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.UID == "" || tmpl.ResourceVersion == "" {
+		return engine.Parse(body)
+	}
+
+	if v, ok := c.lru.Get(tmpl.UID); ok && v.(cachedTemplate).resourceVersion == tmpl.ResourceVersion {
+		metrics.TemplateCacheHits.Inc()
+		return v.(cachedTemplate).parsed, nil
+	}
+	metrics.TemplateCacheMisses.Inc()
+	parsed, err := engine.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	c.lru.Add(tmpl.UID, cachedTemplate{resourceVersion: tmpl.ResourceVersion, parsed: parsed})
+	return parsed, nil
+}
+
+// Evict removes the ObjectTemplate with uid from the cache.
+func (c *TemplateCache) Evict(uid types.UID) {
+	c.lru.Remove(uid)
+}
+
+// Len returns the number of ObjectTemplates in the cache.
+func (c *TemplateCache) Len() int {
+	return c.lru.Len()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/metrics"
+)
+
+func TestTemplateCache(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := NewTemplateCache(2)
+	tmpl := func(uid, resourceVersion, body string) *v1.ObjectTemplate {
+		return &v1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), ResourceVersion: resourceVersion},
+			Spec:       v1.ObjectTemplateSpec{Spec: body},
+		}
+	}
+	execute := func(t *v1.ObjectTemplate) string {
+		parsed, err := cache.Parse(t, t.Spec.Spec)
+		g.Expect(err).NotTo(HaveOccurred())
+		out, err := parsed.Execute(map[string]string{"v": "x"})
+		g.Expect(err).NotTo(HaveOccurred())
+		return string(out)
+	}
+	hits := func() float64 { return testutil.ToFloat64(metrics.TemplateCacheHits) }
+	misses := func() float64 { return testutil.ToFloat64(metrics.TemplateCacheMisses) }
+	h, m := hits(), misses()
+
+	g.Expect(execute(tmpl("a", "1", "a: {{ .v }}"))).To(Equal("a: x"))
+	g.Expect(execute(tmpl("a", "1", "ignored"))).To(Equal("a: x"))
+	g.Expect(hits() - h).To(Equal(1.0))
+	g.Expect(misses() - m).To(Equal(1.0))
+
+	// A new resourceVersion is parsed again.
+	g.Expect(execute(tmpl("a", "2", "b: {{ .v }}"))).To(Equal("b: x"))
+	g.Expect(misses() - m).To(Equal(2.0))
+
+	// The least recently used template is evicted once the cache is full.
+	execute(tmpl("b", "1", "c: {{ .v }}"))
+	execute(tmpl("c", "1", "d: {{ .v }}"))
+	g.Expect(cache.Len()).To(Equal(2))
+	g.Expect(execute(tmpl("a", "2", "e: {{ .v }}"))).To(Equal("e: x"))
+
+	// Deleted templates are evicted.
+	cache.Evict("c")
+	g.Expect(execute(tmpl("c", "1", "f: {{ .v }}"))).To(Equal("f: x"))
+
+	// Templates without a UID are not cached.
+	before := cache.Len()
+	g.Expect(execute(&v1.ObjectTemplate{Spec: v1.ObjectTemplateSpec{Spec: "g: {{ .v }}"}})).To(Equal("g: x"))
+	g.Expect(cache.Len()).To(Equal(before))
+}
+
+func TestTemplateCacheConcurrentUse(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := NewTemplateCache(1)
+	for _, engine := range []v1.TemplateEngine{v1.GoTemplateEngine, v1.SubstitutionEngine} {
+		tmpl := &v1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(engine), ResourceVersion: "1"},
+			Spec:       v1.ObjectTemplateSpec{Engine: engine},
+		}
+		body := "value: {{ .v }}"
+		if engine == v1.SubstitutionEngine {
+			body = "value: ${v}"
+		}
+
+		var wg sync.WaitGroup
+		outputs := make([]string, 20)
+		for i := range outputs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				parsed, err := cache.Parse(tmpl, body)
+				if err != nil {
+					return
+				}
+				out, _ := parsed.Execute(map[string]string{"v": string(rune('a' + i))})
+				outputs[i] = string(out)
+			}()
+		}
+		wg.Wait()
+		for i, out := range outputs {
+			g.Expect(out).To(HavePrefix("value: "+string(rune('a'+i))), "%s", engine)
+		}
+	}
+}
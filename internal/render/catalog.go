@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	v1 "github.com/james226/braid/api/v1"
+	v2 "github.com/james226/braid/api/v2"
+)
+
+var (
+	catalogScheme = runtime.NewScheme()
+	catalogCodecs = serializer.NewCodecFactory(catalogScheme)
+)
+
+func init() {
+	if err := v1.AddToScheme(catalogScheme); err != nil {
+		panic(err)
+	}
+	if err := v2.AddToScheme(catalogScheme); err != nil {
+		panic(err)
+	}
+}
+
+// Catalog is an in-memory Resolver populated from manifests, used to render
+// Applications without a cluster.
+type Catalog struct {
+	Applications         []*v1.Application
+	ApplicationTemplates []*v1.ApplicationTemplate
+	ObjectTemplates      []*v1.ObjectTemplate
+}
+
+// Load decodes every braid object in the YAML stream read from r and adds it
+// to the catalog. Documents of other kinds are ignored. Objects without a
+// namespace are placed in namespace.
+func (c *Catalog) Load(r io.Reader, namespace string) error {
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := catalogCodecs.UniversalDeserializer().Decode(doc, nil, nil)
+		if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := c.Add(obj.(client.Object), namespace); err != nil {
+			return err
+		}
+	}
+}
+
+// Add places obj in the catalog, converting v2 objects to v1.
+func (c *Catalog) Add(obj client.Object, namespace string) error {
+	if spoke, ok := obj.(conversion.Convertible); ok {
+		hub, err := hubFor(spoke)
+		if err != nil {
+			return err
+		}
+		if err := spoke.ConvertTo(hub); err != nil {
+			return err
+		}
+		obj = hub.(client.Object)
+	}
+
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+
+	switch o := obj.(type) {
+	case *v1.Application:
+		c.Applications = append(c.Applications, o)
+	case *v1.ApplicationTemplate:
+		c.ApplicationTemplates = append(c.ApplicationTemplates, o)
+	case *v1.ObjectTemplate:
+		c.ObjectTemplates = append(c.ObjectTemplates, o)
+	default:
+		return fmt.Errorf("unsupported object %T", obj)
+	}
+	return nil
+}
+
+func hubFor(spoke conversion.Convertible) (conversion.Hub, error) {
+	switch spoke.(type) {
+	case *v2.Application:
+		return &v1.Application{}, nil
+	case *v2.ApplicationTemplate:
+		return &v1.ApplicationTemplate{}, nil
+	case *v2.ObjectTemplate:
+		return &v1.ObjectTemplate{}, nil
+	}
+	return nil, fmt.Errorf("no hub type for %T", spoke)
+}
+
+// ApplicationTemplate returns the named ApplicationTemplate from the catalog.
+func (c *Catalog) ApplicationTemplate(_ context.Context, namespace, name string) (*v1.ApplicationTemplate, error) {
+	for _, t := range c.ApplicationTemplates {
+		if t.Namespace == namespace && t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(v1.GroupVersion.WithResource("applicationtemplates").GroupResource(),
+		types.NamespacedName{Namespace: namespace, Name: name}.String())
+}
+
+// ObjectTemplate returns the named ObjectTemplate from the catalog.
+func (c *Catalog) ObjectTemplate(_ context.Context, namespace, name string) (*v1.ObjectTemplate, error) {
+	for _, t := range c.ObjectTemplates {
+		if t.Namespace == namespace && t.Name == name {
+			return t, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(v1.GroupVersion.WithResource("objecttemplates").GroupResource(),
+		types.NamespacedName{Namespace: namespace, Name: name}.String())
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// ClientResolver resolves templates from the Kubernetes API.
+type ClientResolver struct {
+	client.Reader
+}
+
+// ApplicationTemplate fetches the named ApplicationTemplate.
+func (r ClientResolver) ApplicationTemplate(ctx context.Context, namespace, name string) (*v1.ApplicationTemplate, error) {
+	var tmpl v1.ApplicationTemplate
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &tmpl); err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+// ObjectTemplate fetches the named ObjectTemplate.
+func (r ClientResolver) ObjectTemplate(ctx context.Context, namespace, name string) (*v1.ObjectTemplate, error) {
+	var tmpl v1.ObjectTemplate
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &tmpl); err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
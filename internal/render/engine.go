@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// Engine renders the body of an ObjectTemplate.
+type Engine interface {
+	// Execute renders body with variables into a YAML stream.
+	Execute(body string, variables map[string]string) ([]byte, error)
+
+	// Parse prepares body to be executed any number of times.
+	Parse(body string) (Parsed, error)
+
+	// Variables returns the sorted names of the variables body references.
+	Variables(body string) ([]string, error)
+}
+
+// Parsed is a body parsed by an Engine. It is safe for concurrent use.
+type Parsed interface {
+	// Execute renders the body with variables into a YAML stream.
+	Execute(variables map[string]string) ([]byte, error)
+}
+
+// EngineFor returns the Engine with the given name. An empty name selects
+// the Go template engine.
+func EngineFor(name v1.TemplateEngine) (Engine, error) {
+	switch name {
+	case v1.GoTemplateEngine, "":
+		return GoTemplate{}, nil
+	case v1.SubstitutionEngine:
+		return Substitution{}, nil
+	}
+	return nil, fmt.Errorf("unknown template engine %q", name)
+}
+
+// GoTemplate renders bodies as Go text/templates with the variables as dot.
+// Missing variables render as empty strings.
+type GoTemplate struct{}
+
+// Execute implements Engine.
+func (e GoTemplate) Execute(body string, variables map[string]string) ([]byte, error) {
+	parsed, err := e.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Execute(variables)
+}
+
+// Parse implements Engine.
+func (GoTemplate) Parse(body string) (Parsed, error) {
+	tmpl, err := template.New("object").Option("missingkey=zero").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	return parsedGoTemplate{tmpl}, nil
+}
+
+type parsedGoTemplate struct {
+	tmpl *template.Template
+}
+
+func (p parsedGoTemplate) Execute(variables map[string]string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := p.tmpl.Execute(buf, variables); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Variables implements Engine.
+func (GoTemplate) Variables(body string) ([]string, error) {
+	return ReferencedVariables(body)
+}
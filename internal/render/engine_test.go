@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+func TestSubstitutionKeepsStructure(t *testing.T) {
+	g := NewWithT(t)
+
+	out, err := Substitution{}.Execute(`
+replicas: ${replicas}
+image: nginx:${tag}
+tag: "${tag}"
+command: ["sh", "-c", "echo $${HOME} ${message}"]
+`, map[string]string{"replicas": "3", "tag": "1.27", "message": "a: b\n- c"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var spec interface{}
+	g.Expect(yaml.Unmarshal(out, &spec)).To(Succeed())
+	g.Expect(spec).To(Equal(map[string]interface{}{
+		"replicas": int64(3),
+		"image":    "nginx:1.27",
+		"tag":      "1.27",
+		"command":  []interface{}{"sh", "-c", "echo ${HOME} a: b\n- c"},
+	}))
+}
+
+func TestSubstitutionUndefinedVariable(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := Substitution{}.Execute("a: 1\nb: ${missing}\n", nil)
+	g.Expect(err).To(MatchError(`line 2: undefined variable "missing"`))
+}
+
+func TestEnginesRenderEmptyBodiesToNothing(t *testing.T) {
+	for _, body := range []string{"", "\n", "# only a comment\n", "---\n# comment\n---\n"} {
+		for _, engine := range []Engine{GoTemplate{}, Substitution{}} {
+			g := NewWithT(t)
+
+			out, err := engine.Execute(body, nil)
+			g.Expect(err).NotTo(HaveOccurred(), "%T %q", engine, body)
+			var spec interface{}
+			g.Expect(yaml.Unmarshal(out, &spec)).To(Succeed())
+			g.Expect(spec).To(BeNil(), "%T %q", engine, body)
+		}
+	}
+}
+
+func TestSubstitutionVariables(t *testing.T) {
+	g := NewWithT(t)
+
+	names, err := Substitution{}.Variables("a: ${b}\n---\n${c}: x-${b}-$${d}\n")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"b", "c"}))
+}
+
+func TestTemplateUsesEngine(t *testing.T) {
+	g := NewWithT(t)
+
+	app := &v1.Application{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	tmpl := &v1.ObjectTemplate{Spec: v1.ObjectTemplateSpec{
+		ApiVersion: "v1",
+		Kind:       "Service",
+		Engine:     v1.SubstitutionEngine,
+		Spec:       "ports:\n  - port: ${port}\n",
+	}}
+
+	object, err := Template(app, tmpl, map[string]string{"port": "80"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(object.Object["spec"]).To(Equal(map[string]interface{}{
+		"ports": []interface{}{map[string]interface{}{"port": int64(80)}},
+	}))
+
+	tmpl.Spec.Engine = "Jsonnet"
+	_, err = Template(app, tmpl, nil)
+	g.Expect(err).To(MatchError(`unknown template engine "Jsonnet"`))
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import "errors"
+
+// InvalidError reports that the templates or the Application cannot be
+// rendered as written, such as a template that does not parse or a required
+// variable that is not set. Rendering them again fails the same way.
+type InvalidError struct {
+	// Template is the name of the ObjectTemplate that could not be rendered
+	Template string
+	Err      error
+}
+
+func (e *InvalidError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidError) Unwrap() error {
+	return e.Err
+}
+
+// IsInvalid reports whether err, or an error it wraps, is an InvalidError.
+func IsInvalid(err error) bool {
+	var invalid *InvalidError
+	return errors.As(err, &invalid)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// Hash returns the SHA-256 of object together with the settings that decide
+// how it is written, so a change to either changes the hash.
+func Hash(object Object) (string, error) {
+	data, err := json.Marshal(struct {
+		Object                    map[string]interface{}        `json:"object"`
+		ConflictPolicy            v1.ConflictPolicy             `json:"conflictPolicy,omitempty"`
+		ApplyStrategy             v1.ApplyStrategy              `json:"applyStrategy,omitempty"`
+		IgnoreDifferences         []v1.IgnoreDifference         `json:"ignoreDifferences,omitempty"`
+		RecreateOnImmutableChange *v1.RecreateOnImmutableChange `json:"recreateOnImmutableChange,omitempty"`
+	}{
+		Object:                    object.Object,
+		ConflictPolicy:            object.ConflictPolicy,
+		ApplyStrategy:             object.ApplyStrategy,
+		IgnoreDifferences:         object.IgnoreDifferences,
+		RecreateOnImmutableChange: object.RecreateOnImmutableChange,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SetHash records the hash of object in its ManifestHashAnnotation and
+// returns it. The owner of object has to be set first, as it is part of the
+// hash.
+func SetHash(object Object) (string, error) {
+	hash, err := Hash(object)
+	if err != nil {
+		return "", err
+	}
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[v1.ManifestHashAnnotation] = hash
+	object.SetAnnotations(annotations)
+	return hash, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+func TestSetHash(t *testing.T) {
+	g := NewWithT(t)
+
+	object := func() Object {
+		return Object{Unstructured: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "demo"},
+			"data":       map[string]interface{}{"key": "value"},
+		}}}
+	}
+
+	o := object()
+	hash, err := SetHash(o)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hash).To(HaveLen(64))
+	g.Expect(o.GetAnnotations()).To(Equal(map[string]string{v1.ManifestHashAnnotation: hash}))
+
+	hashWith := func(change func(o *Object)) string {
+		o := object()
+		change(&o)
+		h, err := Hash(o)
+		g.Expect(err).NotTo(HaveOccurred())
+		return h
+	}
+	g.Expect(hashWith(func(o *Object) {})).To(Equal(hash))
+	g.Expect(hashWith(func(o *Object) { o.Object["data"] = map[string]interface{}{"key": "other"} })).NotTo(Equal(hash))
+	g.Expect(hashWith(func(o *Object) { o.ConflictPolicy = v1.ForceConflictPolicy })).NotTo(Equal(hash))
+	g.Expect(hashWith(func(o *Object) { o.ApplyStrategy = v1.ReplaceStrategy })).NotTo(Equal(hash))
+}
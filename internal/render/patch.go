@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// Patch applies every patch targeting object, in order. Patches that change
+// the apiVersion, kind, namespace or name of object are rejected.
+func Patch(object *unstructured.Unstructured, patches []v1.Patch) error {
+	for i, p := range patches {
+		if !targets(p.Target, object) {
+			continue
+		}
+
+		patch, err := yaml.YAMLToJSON([]byte(p.Patch))
+		if err != nil {
+			return fmt.Errorf("patch %d: %w", i, err)
+		}
+		original, err := json.Marshal(object.Object)
+		if err != nil {
+			return err
+		}
+
+		var patched []byte
+		switch p.Type {
+		case v1.StrategicMergePatchType, "":
+			patched, err = strategicMerge(object, original, patch)
+		case v1.JSON6902PatchType:
+			var ops jsonpatch.Patch
+			ops, err = jsonpatch.DecodePatch(patch)
+			if err == nil {
+				patched, err = ops.Apply(original)
+			}
+		default:
+			err = fmt.Errorf("unknown patch type %q", p.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("patch %d: %w", i, err)
+		}
+
+		result := &unstructured.Unstructured{}
+		if err := json.Unmarshal(patched, &result.Object); err != nil {
+			return fmt.Errorf("patch %d: %w", i, err)
+		}
+		// The identity of the object decides where and with which owner
+		// braid writes it, so patches may only change its content.
+		if identity(result) != identity(object) {
+			return fmt.Errorf("patch %d: changes %s to %s; patches may not change apiVersion, kind, namespace or name",
+				i, identity(object), identity(result))
+		}
+		object.Object = result.Object
+	}
+	return nil
+}
+
+// identity describes the apiVersion, kind, namespace and name of object.
+func identity(object *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s %s %s/%s", object.GetAPIVersion(), object.GetKind(), object.GetNamespace(), object.GetName())
+}
+
+// strategicMerge uses strategic merge for kinds built into Kubernetes and
+// falls back to JSON merge patch for kinds it has no schema for.
+func strategicMerge(object *unstructured.Unstructured, original, patch []byte) ([]byte, error) {
+	typed, err := clientgoscheme.Scheme.New(object.GroupVersionKind())
+	if err != nil {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	return strategicpatch.StrategicMergePatch(original, patch, typed)
+}
+
+func targets(t v1.PatchTarget, object *unstructured.Unstructured) bool {
+	gvk := object.GroupVersionKind()
+	return (t.Group == "" || t.Group == gvk.Group) &&
+		(t.Version == "" || t.Version == gvk.Version) &&
+		(t.Kind == "" || t.Kind == gvk.Kind) &&
+		(t.Name == "" || t.Name == object.GetName())
+}
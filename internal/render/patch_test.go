@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+func deployment() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "image": "nginx"},
+						map[string]interface{}{"name": "proxy", "image": "envoy"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestPatchStrategicMergeUsesMergeKeys(t *testing.T) {
+	g := NewWithT(t)
+	object := deployment()
+
+	err := Patch(object, []v1.Patch{{Patch: `
+metadata:
+  annotations:
+    team: payments
+spec:
+  template:
+    spec:
+      containers:
+        - name: web
+          resources:
+            limits:
+              memory: 1Gi
+`}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(object.GetAnnotations()).To(Equal(map[string]string{"team": "payments"}))
+
+	containers, _, _ := unstructured.NestedSlice(object.Object, "spec", "template", "spec", "containers")
+	g.Expect(containers).To(ConsistOf(
+		map[string]interface{}{"name": "web", "image": "nginx",
+			"resources": map[string]interface{}{"limits": map[string]interface{}{"memory": "1Gi"}}},
+		map[string]interface{}{"name": "proxy", "image": "envoy"},
+	))
+}
+
+func TestPatchJSON6902(t *testing.T) {
+	g := NewWithT(t)
+	object := deployment()
+
+	err := Patch(object, []v1.Patch{{
+		Type: v1.JSON6902PatchType,
+		Patch: `
+- op: add
+  path: /spec/template/spec/tolerations
+  value:
+    - key: dedicated
+      operator: Exists
+- op: remove
+  path: /spec/template/spec/containers/1
+`,
+	}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	containers, _, _ := unstructured.NestedSlice(object.Object, "spec", "template", "spec", "containers")
+	g.Expect(containers).To(HaveLen(1))
+	tolerations, _, _ := unstructured.NestedSlice(object.Object, "spec", "template", "spec", "tolerations")
+	g.Expect(tolerations).To(Equal([]interface{}{map[string]interface{}{"key": "dedicated", "operator": "Exists"}}))
+}
+
+func TestPatchRejectsIdentityChanges(t *testing.T) {
+	patches := map[string]v1.Patch{
+		"namespace":  {Type: v1.JSON6902PatchType, Patch: `[{"op": "replace", "path": "/metadata/namespace", "value": "kube-system"}]`},
+		"kind":       {Type: v1.JSON6902PatchType, Patch: `[{"op": "replace", "path": "/kind", "value": "Secret"}]`},
+		"name":       {Patch: "metadata:\n  name: other\n"},
+		"apiVersion": {Patch: "apiVersion: apps/v1beta1\n"},
+	}
+	for field, patch := range patches {
+		t.Run(field, func(t *testing.T) {
+			g := NewWithT(t)
+			object := deployment()
+
+			err := Patch(object, []v1.Patch{patch})
+			g.Expect(err).To(MatchError(ContainSubstring("patches may not change apiVersion, kind, namespace or name")))
+			g.Expect(identity(object)).To(Equal("apps/v1 Deployment default/web"))
+		})
+	}
+}
+
+func TestPatchUnknownKindUsesMergePatch(t *testing.T) {
+	g := NewWithT(t)
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.dev/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]interface{}{"name": "web"},
+		"spec":       map[string]interface{}{"items": []interface{}{"a", "b"}, "size": int64(1)},
+	}}
+
+	err := Patch(object, []v1.Patch{{Patch: `{"spec": {"items": ["c"]}}`}})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(object.Object["spec"]).To(Equal(map[string]interface{}{"items": []interface{}{"c"}, "size": float64(1)}))
+}
+
+func TestPatchTarget(t *testing.T) {
+	g := NewWithT(t)
+	object := deployment()
+
+	err := Patch(object, []v1.Patch{
+		{Target: v1.PatchTarget{Kind: "Service"}, Patch: "metadata: {labels: {a: b}}"},
+		{Target: v1.PatchTarget{Group: "apps", Kind: "Deployment", Name: "other"}, Patch: "metadata: {labels: {a: b}}"},
+		{Target: v1.PatchTarget{Group: "apps", Kind: "Deployment", Name: "web"}, Patch: "metadata: {labels: {c: d}}"},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(object.GetLabels()).To(Equal(map[string]string{"c": "d"}))
+}
+
+func TestPatchInvalid(t *testing.T) {
+	g := NewWithT(t)
+
+	err := Patch(deployment(), []v1.Patch{{Type: v1.JSON6902PatchType, Patch: "- op: remove\n  path: /spec/missing\n"}})
+	g.Expect(err).To(MatchError(ContainSubstring("patch 0")))
+}
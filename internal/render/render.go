@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render turns an Application and the templates it references into
+// the Kubernetes objects braid manages. It is shared by the controller and
+// the braid CLI so both produce identical manifests.
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/james226/braid/api/v1"
+	"github.com/james226/braid/internal/metrics"
+	"github.com/james226/braid/internal/tracing"
+)
+
+// Resolver looks up the templates referenced while rendering an Application.
+type Resolver interface {
+	ApplicationTemplate(ctx context.Context, namespace, name string) (*v1.ApplicationTemplate, error)
+	ObjectTemplate(ctx context.Context, namespace, name string) (*v1.ObjectTemplate, error)
+}
+
+// Object is a rendered manifest and the ObjectTemplate that produced it.
+type Object struct {
+	Template string
+	// TemplateVersion is the resourceVersion of the ObjectTemplate
+	TemplateVersion string
+	// ConflictPolicy and ApplyStrategy set on the ApplicationTemplate object
+	ConflictPolicy v1.ConflictPolicy
+	ApplyStrategy  v1.ApplyStrategy
+	// IgnoreDifferences of the ObjectTemplate and the ApplicationTemplate
+	// object that target this object
+	IgnoreDifferences []v1.IgnoreDifference
+	// RecreateOnImmutableChange of the ObjectTemplate
+	RecreateOnImmutableChange *v1.RecreateOnImmutableChange
+	*unstructured.Unstructured
+}
+
+// Application resolves the ApplicationTemplate of app and renders every
+// object it lists.
+func Application(ctx context.Context, r Resolver, app *v1.Application) ([]Object, error) {
+	tmpl, err := r.ApplicationTemplate(ctx, app.Namespace, app.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	return Objects(ctx, r, app, tmpl)
+}
+
+// Objects renders every object listed in tmpl for app and applies the
+// patches of app to them.
+func Objects(ctx context.Context, r Resolver, app *v1.Application, tmpl *v1.ApplicationTemplate) ([]Object, error) {
+	var objects []Object
+	seen := map[string]string{}
+	for _, o := range tmpl.Spec.Objects {
+		_, span := tracing.Start(ctx, "ResolveObjectTemplate", tracing.ObjectTemplate.String(o.Template))
+		objectTemplate, err := r.ObjectTemplate(ctx, app.Namespace, o.Template)
+		tracing.End(span, err)
+		if err != nil {
+			return nil, err
+		}
+
+		_, span = tracing.Start(ctx, "RenderObjectTemplate",
+			tracing.ObjectTemplate.String(o.Template),
+			tracing.TemplateRevision.String(objectTemplate.ResourceVersion))
+		start := time.Now()
+		var rendered []Object
+		if objectTemplate.Spec.Manifests != "" {
+			rendered, err = Manifests(app, objectTemplate, Variables(o, app))
+		} else {
+			var object Object
+			object, err = Template(app, objectTemplate, Variables(o, app))
+			rendered = []Object{object}
+		}
+		metrics.RenderDuration.WithLabelValues(app.Namespace, o.Template).Observe(time.Since(start).Seconds())
+		tracing.End(span, err)
+		if err != nil {
+			metrics.RenderFailures.WithLabelValues(app.Namespace, o.Template).Inc()
+			return nil, &InvalidError{o.Template, fmt.Errorf("rendering ObjectTemplate %q: %w", o.Template, err)}
+		}
+
+		for _, object := range rendered {
+			object.TemplateVersion = objectTemplate.ResourceVersion
+			object.ConflictPolicy = o.ConflictPolicy
+			object.ApplyStrategy = o.ApplyStrategy
+			object.RecreateOnImmutableChange = objectTemplate.Spec.RecreateOnImmutableChange
+			if err := Patch(object.Unstructured, app.Spec.Patches); err != nil {
+				return nil, &InvalidError{o.Template, fmt.Errorf("patching ObjectTemplate %q: %w", o.Template, err)}
+			}
+			for _, d := range slices.Concat(objectTemplate.Spec.IgnoreDifferences, o.IgnoreDifferences) {
+				if targets(d.Target, object.Unstructured) {
+					object.IgnoreDifferences = append(object.IgnoreDifferences, d)
+				}
+			}
+			if o.DeletionPolicy != "" && o.DeletionPolicy != v1.DeleteDeletionPolicy {
+				annotations := object.GetAnnotations()
+				if annotations == nil {
+					annotations = map[string]string{}
+				}
+				annotations[v1.DeletionPolicyAnnotation] = string(o.DeletionPolicy)
+				object.SetAnnotations(annotations)
+			}
+
+			key := Key(object.Unstructured)
+			if other, ok := seen[key]; ok {
+				return nil, &InvalidError{o.Template, fmt.Errorf("ObjectTemplates %q and %q both render %s", other, o.Template, key)}
+			}
+			seen[key] = o.Template
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+// Key identifies object by group, kind, namespace and name.
+func Key(object *unstructured.Unstructured) string {
+	gvk := object.GroupVersionKind()
+	return fmt.Sprintf("%s/%s %s/%s", gvk.Group, gvk.Kind, object.GetNamespace(), object.GetName())
+}
+
+// Variables merges the defaults set on an ApplicationTemplate object with the
+// variables of the Application, which take precedence.
+func Variables(o v1.ApplicationObject, app *v1.Application) map[string]string {
+	variables := make(map[string]string)
+
+	for k, v := range o.Variables {
+		variables[k] = v
+	}
+
+	for k, v := range app.Spec.Variables {
+		variables[k] = v
+	}
+
+	return variables
+}
+
+// Template renders a single ObjectTemplate for app with the given variables.
+func Template(app *v1.Application, tmpl *v1.ObjectTemplate, variables map[string]string) (Object, error) {
+	parsed, err := Templates.Parse(tmpl, tmpl.Spec.Spec)
+	if err != nil {
+		return Object{}, err
+	}
+	variables, err = ResolveVariables(tmpl, variables)
+	if err != nil {
+		return Object{}, err
+	}
+	out, err := parsed.Execute(variables)
+	if err != nil {
+		return Object{}, err
+	}
+	var spec interface{}
+	if err := yaml.Unmarshal(out, &spec); err != nil {
+		return Object{}, err
+	}
+
+	groupVersion, err := schema.ParseGroupVersion(tmpl.Spec.ApiVersion)
+	if err != nil {
+		return Object{}, err
+	}
+
+	object := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	object.SetGroupVersionKind(groupVersion.WithKind(tmpl.Spec.Kind))
+	object.SetName(app.Name)
+	object.SetNamespace(app.Namespace)
+	object.Object["spec"] = spec
+
+	return Object{Template: tmpl.Name, Unstructured: object}, nil
+}
+
+// SetOwner makes app the controller of object, so the object is garbage
+// collected together with the Application.
+func SetOwner(object *unstructured.Unstructured, app *v1.Application) {
+	object.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: v1.GroupVersion.String(),
+		Kind:       "Application",
+		Name:       app.Name,
+		UID:        app.UID,
+		Controller: ptr.To(true),
+	}})
+}
+
+// Manifests renders a multi-document ObjectTemplate for app, returning an
+// object per document. Objects are placed in the namespace of app and
+// objects without a name are named after it.
+func Manifests(app *v1.Application, tmpl *v1.ObjectTemplate, variables map[string]string) ([]Object, error) {
+	parsed, err := Templates.Parse(tmpl, tmpl.Spec.Manifests)
+	if err != nil {
+		return nil, err
+	}
+	variables, err = ResolveVariables(tmpl, variables)
+	if err != nil {
+		return nil, err
+	}
+	out, err := parsed.Execute(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []Object
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(out)))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content := map[string]interface{}{}
+		if err := yaml.Unmarshal(doc, &content); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if len(content) == 0 {
+			continue
+		}
+
+		object := &unstructured.Unstructured{Object: content}
+		if object.GetAPIVersion() == "" || object.GetKind() == "" {
+			return nil, fmt.Errorf("document %d: apiVersion and kind are required", i)
+		}
+		if object.GetName() == "" {
+			object.SetName(app.Name)
+		}
+		object.SetNamespace(app.Namespace)
+
+		objects = append(objects, Object{Template: tmpl.Name, Unstructured: object})
+	}
+}
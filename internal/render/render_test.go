@@ -0,0 +1,330 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+func TestVariablesApplicationTakesPrecedence(t *testing.T) {
+	g := NewWithT(t)
+
+	app := &v1.Application{Spec: v1.ApplicationSpec{Variables: map[string]string{"tag": "2", "extra": "x"}}}
+	o := v1.ApplicationObject{Variables: map[string]string{"tag": "1", "image": "nginx"}}
+
+	g.Expect(Variables(o, app)).To(Equal(map[string]string{"tag": "2", "image": "nginx", "extra": "x"}))
+}
+
+func TestApplicationFromCatalog(t *testing.T) {
+	g := NewWithT(t)
+
+	catalog := &Catalog{}
+	g.Expect(catalog.Load(strings.NewReader(`
+apiVersion: braid.james-parker.dev/v1
+kind: ObjectTemplate
+metadata:
+  name: pod
+spec:
+  apiVersion: v1
+  kind: Pod
+  spec: |
+    containers:
+      - name: app
+        image: {{ .image }}
+---
+apiVersion: braid.james-parker.dev/v1
+kind: ApplicationTemplate
+metadata:
+  name: app
+spec:
+  objects:
+    - template: pod
+      variables:
+        image: nginx
+`), "default")).To(Succeed())
+
+	app := &v1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec:       v1.ApplicationSpec{Template: "app"},
+	}
+	objects, err := Application(context.Background(), catalog, app)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objects).To(HaveLen(1))
+	g.Expect(objects[0].Template).To(Equal("pod"))
+	g.Expect(objects[0].GetKind()).To(Equal("Pod"))
+	g.Expect(objects[0].GetName()).To(Equal("demo"))
+	g.Expect(objects[0].Object["spec"]).To(Equal(map[string]interface{}{
+		"containers": []interface{}{map[string]interface{}{"name": "app", "image": "nginx"}},
+	}))
+
+	app.Spec.Template = "missing"
+	_, err = Application(context.Background(), catalog, app)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(IsInvalid(err)).To(BeFalse())
+}
+
+func TestReferencedVariables(t *testing.T) {
+	g := NewWithT(t)
+
+	names, err := ReferencedVariables(`
+image: {{ .image }}:{{ .tag | printf "%s" }}
+{{- if .debug }}
+args: [--debug]
+{{- end }}
+{{- with .resources }}
+resources: {{ .limits }}
+{{- end }}
+{{- range .ports }}
+- {{ .port }} {{ $.protocol }}
+{{- end }}
+name: {{ index . "full-name" }}
+`)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"debug", "full-name", "image", "ports", "protocol", "resources", "tag"}))
+}
+
+func TestResolveVariables(t *testing.T) {
+	g := NewWithT(t)
+
+	tmpl := &v1.ObjectTemplate{Spec: v1.ObjectTemplateSpec{
+		Variables: []string{"image", "replicas", "debug", "resources", "ports"},
+		VariableDefinitions: []v1.VariableDefinition{
+			{Name: "image", Required: true},
+			{Name: "replicas", Type: v1.VariableTypeInteger, Default: &apiextensionsv1.JSON{Raw: []byte(`2`)}},
+			{Name: "debug", Type: v1.VariableTypeBoolean, Default: &apiextensionsv1.JSON{Raw: []byte(`false`)}},
+			{Name: "resources", Type: v1.VariableTypeObject, Default: &apiextensionsv1.JSON{Raw: []byte(`{"cpu": "1"}`)}},
+			{Name: "ports", Type: v1.VariableTypeArray},
+		},
+	}}
+
+	variables, err := ResolveVariables(tmpl, map[string]string{"image": "nginx", "debug": "true"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(variables).To(Equal(map[string]string{
+		"image":     "nginx",
+		"replicas":  "2",
+		"debug":     "true",
+		"resources": `{"cpu":"1"}`,
+	}))
+
+	_, err = ResolveVariables(tmpl, map[string]string{})
+	g.Expect(err).To(MatchError(`variable "image" is required`))
+
+	_, err = ResolveVariables(tmpl, map[string]string{"image": "nginx", "replicas": "two"})
+	g.Expect(err).To(MatchError(`variable "replicas": "two" is not a valid integer`))
+
+	_, err = ResolveVariables(tmpl, map[string]string{"image": "nginx", "ports": `{"http": 80}`})
+	g.Expect(err).To(MatchError(`variable "ports": "{\"http\": 80}" is not a valid array`))
+}
+
+func TestTemplateAppliesVariableDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	app := &v1.Application{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team"}}
+	tmpl := &v1.ObjectTemplate{Spec: v1.ObjectTemplateSpec{
+		ApiVersion: "apps/v1",
+		Kind:       "Deployment",
+		Spec:       "replicas: {{ .replicas }}",
+		Variables:  []string{"replicas"},
+		VariableDefinitions: []v1.VariableDefinition{
+			{Name: "replicas", Type: v1.VariableTypeInteger, Default: &apiextensionsv1.JSON{Raw: []byte(`3`)}},
+		},
+	}}
+
+	object, err := Template(app, tmpl, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(object.Object["spec"]).To(Equal(map[string]interface{}{"replicas": int64(3)}))
+
+	_, err = Template(app, tmpl, map[string]string{"replicas": "many"})
+	g.Expect(err).To(MatchError(`variable "replicas": "many" is not a valid integer`))
+
+	tmpl.Spec.Manifests = "apiVersion: v1\nkind: ConfigMap\n"
+	_, err = Manifests(app, tmpl, map[string]string{"replicas": "many"})
+	g.Expect(err).To(MatchError(`variable "replicas": "many" is not a valid integer`))
+}
+
+func TestManifests(t *testing.T) {
+	g := NewWithT(t)
+
+	app := &v1.Application{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team"}}
+	tmpl := &v1.ObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-service"},
+		Spec: v1.ObjectTemplateSpec{Manifests: `
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: {{ .replicas }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+  namespace: other
+---
+`},
+	}
+
+	objects, err := Manifests(app, tmpl, map[string]string{"replicas": "2"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objects).To(HaveLen(2))
+	g.Expect(objects[0].Template).To(Equal("web-service"))
+	g.Expect(Key(objects[0].Unstructured)).To(Equal("apps/Deployment team/web"))
+	g.Expect(objects[0].Object["spec"]).To(Equal(map[string]interface{}{"replicas": int64(2)}))
+	g.Expect(Key(objects[1].Unstructured)).To(Equal("/ConfigMap team/web-config"))
+
+	tmpl.Spec.Manifests = "metadata:\n  name: x\n"
+	_, err = Manifests(app, tmpl, nil)
+	g.Expect(err).To(MatchError("document 0: apiVersion and kind are required"))
+}
+
+func TestObjectsRejectsDuplicates(t *testing.T) {
+	g := NewWithT(t)
+
+	catalog := &Catalog{}
+	g.Expect(catalog.Load(strings.NewReader(`
+apiVersion: braid.james-parker.dev/v1
+kind: ObjectTemplate
+metadata:
+  name: service
+spec:
+  apiVersion: v1
+  kind: Service
+---
+apiVersion: braid.james-parker.dev/v1
+kind: ObjectTemplate
+metadata:
+  name: bundle
+spec:
+  manifests: |
+    apiVersion: v1
+    kind: Service
+---
+apiVersion: braid.james-parker.dev/v1
+kind: ApplicationTemplate
+metadata:
+  name: app
+spec:
+  objects:
+    - template: service
+    - template: bundle
+`), "default")).To(Succeed())
+
+	app := &v1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec:       v1.ApplicationSpec{Template: "app"},
+	}
+	_, err := Application(context.Background(), catalog, app)
+	g.Expect(err).To(MatchError(`ObjectTemplates "service" and "bundle" both render /Service default/demo`))
+	g.Expect(IsInvalid(err)).To(BeTrue())
+}
+
+func TestObjectsCollectIgnoreDifferences(t *testing.T) {
+	g := NewWithT(t)
+
+	catalog := &Catalog{}
+	g.Expect(catalog.Load(strings.NewReader(`
+apiVersion: braid.james-parker.dev/v1
+kind: ObjectTemplate
+metadata:
+  name: bundle
+spec:
+  manifests: |
+    apiVersion: apps/v1
+    kind: Deployment
+    ---
+    apiVersion: v1
+    kind: Service
+  ignoreDifferences:
+    - target:
+        kind: Deployment
+      jsonPointers: [/spec/replicas]
+---
+apiVersion: braid.james-parker.dev/v1
+kind: ApplicationTemplate
+metadata:
+  name: app
+spec:
+  objects:
+    - template: bundle
+      ignoreDifferences:
+        - managedFieldsManagers: [injector]
+`), "default")).To(Succeed())
+
+	app := &v1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec:       v1.ApplicationSpec{Template: "app"},
+	}
+	objects, err := Application(context.Background(), catalog, app)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objects).To(HaveLen(2))
+	g.Expect(objects[0].IgnoreDifferences).To(Equal([]v1.IgnoreDifference{
+		{Target: v1.PatchTarget{Kind: "Deployment"}, JSONPointers: []string{"/spec/replicas"}},
+		{ManagedFieldsManagers: []string{"injector"}},
+	}))
+	g.Expect(objects[1].IgnoreDifferences).To(Equal([]v1.IgnoreDifference{
+		{ManagedFieldsManagers: []string{"injector"}},
+	}))
+}
+
+func TestObjectsRecordDeletionPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	catalog := &Catalog{}
+	g.Expect(catalog.Load(strings.NewReader(`
+apiVersion: braid.james-parker.dev/v1
+kind: ObjectTemplate
+metadata:
+  name: claim
+spec:
+  manifests: |
+    apiVersion: v1
+    kind: PersistentVolumeClaim
+    metadata:
+      name: {{ .name }}
+---
+apiVersion: braid.james-parker.dev/v1
+kind: ApplicationTemplate
+metadata:
+  name: app
+spec:
+  objects:
+    - template: claim
+      variables: {name: data}
+      deletionPolicy: Retain
+    - template: claim
+      variables: {name: cache}
+      deletionPolicy: Delete
+`), "default")).To(Succeed())
+
+	app := &v1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec:       v1.ApplicationSpec{Template: "app"},
+	}
+	objects, err := Application(context.Background(), catalog, app)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(objects).To(HaveLen(2))
+	g.Expect(objects[0].GetAnnotations()).To(Equal(map[string]string{v1.DeletionPolicyAnnotation: "Retain"}))
+	g.Expect(objects[1].GetAnnotations()).To(BeEmpty())
+}
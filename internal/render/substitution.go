@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// placeholder matches ${name} and the $$ escape for a literal dollar sign.
+var placeholder = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Substitution renders bodies by parsing them as YAML and replacing ${name}
+// placeholders in scalars, so a value can never change the structure of the
+// document. A scalar consisting of a single unquoted placeholder takes the
+// YAML type of the value, making `replicas: ${replicas}` a number; quoted
+// placeholders and placeholders within other text always produce strings.
+// Referencing a variable that is not set is an error.
+type Substitution struct{}
+
+// Execute implements Engine.
+func (e Substitution) Execute(body string, variables map[string]string) ([]byte, error) {
+	parsed, err := e.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Execute(variables)
+}
+
+// Parse implements Engine.
+func (Substitution) Parse(body string) (Parsed, error) {
+	var documents parsedSubstitution
+	err := eachDocument(body, func(doc *yamlv3.Node) error {
+		// Comment-only documents render to nothing, as they do with
+		// GoTemplate.
+		if len(doc.Content) > 0 {
+			documents = append(documents, doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// parsedSubstitution holds the documents of a body, which are copied before
+// placeholders are replaced so they can be executed again.
+type parsedSubstitution []*yamlv3.Node
+
+func (p parsedSubstitution) Execute(variables map[string]string) ([]byte, error) {
+	if len(p) == 0 {
+		return []byte{}, nil
+	}
+
+	var out bytes.Buffer
+	enc := yamlv3.NewEncoder(&out)
+	enc.SetIndent(2)
+	for _, doc := range p {
+		doc = copyNode(doc)
+		if err := substitute(doc, variables); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// copyNode returns a deep copy of the content of n. Aliases keep pointing at
+// the anchors of n, which are only read.
+func copyNode(n *yamlv3.Node) *yamlv3.Node {
+	c := *n
+	c.Content = make([]*yamlv3.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = copyNode(child)
+	}
+	return &c
+}
+
+// Variables implements Engine.
+func (Substitution) Variables(body string) ([]string, error) {
+	seen := map[string]struct{}{}
+	err := eachDocument(body, func(doc *yamlv3.Node) error {
+		return eachScalar(doc, func(n *yamlv3.Node) error {
+			for _, m := range placeholder.FindAllStringSubmatch(n.Value, -1) {
+				if m[1] != "" {
+					seen[m[1]] = struct{}{}
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func eachDocument(body string, fn func(doc *yamlv3.Node) error) error {
+	dec := yamlv3.NewDecoder(strings.NewReader(body))
+	for {
+		var doc yamlv3.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+	}
+}
+
+func eachScalar(n *yamlv3.Node, fn func(n *yamlv3.Node) error) error {
+	if n.Kind == yamlv3.ScalarNode {
+		return fn(n)
+	}
+	for _, child := range n.Content {
+		if err := eachScalar(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func substitute(doc *yamlv3.Node, variables map[string]string) error {
+	return eachScalar(doc, func(n *yamlv3.Node) error {
+		if !placeholder.MatchString(n.Value) {
+			return nil
+		}
+
+		whole := placeholder.FindStringSubmatch(n.Value)
+		quoted := n.Style&(yamlv3.SingleQuotedStyle|yamlv3.DoubleQuotedStyle) != 0
+		if whole[0] == n.Value && whole[1] != "" && !quoted {
+			value, ok := variables[whole[1]]
+			if !ok {
+				return fmt.Errorf("line %d: undefined variable %q", n.Line, whole[1])
+			}
+			// Without a tag the encoder writes the value plainly when it
+			// can, so it is read back with its own YAML type.
+			n.Value = value
+			n.Tag = ""
+			n.Style = 0
+			return nil
+		}
+
+		var err error
+		n.Value = placeholder.ReplaceAllStringFunc(n.Value, func(m string) string {
+			if m == "$$" {
+				return "$"
+			}
+			name := placeholder.FindStringSubmatch(m)[1]
+			value, ok := variables[name]
+			if !ok && err == nil {
+				err = fmt.Errorf("line %d: undefined variable %q", n.Line, name)
+			}
+			return value
+		})
+		n.Tag = "!!str"
+		return err
+	})
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"text/template"
+	"text/template/parse"
+
+	v1 "github.com/james226/braid/api/v1"
+)
+
+// ResolveVariables applies the variable definitions of tmpl to variables. It
+// returns a copy with defaults filled in, or an error if a required variable
+// is missing or a value does not have the declared type.
+func ResolveVariables(tmpl *v1.ObjectTemplate, variables map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(variables))
+	for k, v := range variables {
+		resolved[k] = v
+	}
+
+	for _, d := range tmpl.Spec.VariableDefinitions {
+		value, ok := resolved[d.Name]
+		if !ok {
+			switch {
+			case d.Default != nil:
+				value = defaultValue(d.Default.Raw)
+				resolved[d.Name] = value
+			case d.Required:
+				return nil, fmt.Errorf("variable %q is required", d.Name)
+			default:
+				continue
+			}
+		}
+		if !hasType(value, d.Type) {
+			return nil, fmt.Errorf("variable %q: %q is not a valid %s", d.Name, value, d.Type)
+		}
+	}
+	return resolved, nil
+}
+
+// defaultValue renders a JSON default as a variable value. Strings are
+// unquoted, every other value is kept as compact JSON.
+func defaultValue(raw []byte) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// hasType reports whether value can be read as a variableType.
+func hasType(value string, variableType v1.VariableType) bool {
+	var err error
+	switch variableType {
+	case v1.VariableTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case v1.VariableTypeNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case v1.VariableTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case v1.VariableTypeObject:
+		var object map[string]interface{}
+		err = json.Unmarshal([]byte(value), &object)
+	case v1.VariableTypeArray:
+		var array []interface{}
+		err = json.Unmarshal([]byte(value), &array)
+	}
+	return err == nil
+}
+
+// ReferencedVariables returns the sorted names of the variables a template
+// body reads, either as {{ .name }}, {{ $.name }} or {{ index . "name" }}.
+func ReferencedVariables(spec string) ([]string, error) {
+	tmpl, err := template.New("object").Option("missingkey=zero").Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			walkVariables(t.Tree.Root, true, seen)
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// walkVariables records variable references below node. rootDot reports
+// whether dot still refers to the variables map, which stops being true
+// inside range and with blocks.
+func walkVariables(node parse.Node, rootDot bool, seen map[string]struct{}) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkVariables(child, rootDot, seen)
+		}
+	case *parse.ActionNode:
+		walkVariables(n.Pipe, rootDot, seen)
+	case *parse.IfNode:
+		walkVariables(n.Pipe, rootDot, seen)
+		walkVariables(n.List, rootDot, seen)
+		walkVariables(n.ElseList, rootDot, seen)
+	case *parse.RangeNode:
+		walkVariables(n.Pipe, rootDot, seen)
+		walkVariables(n.List, false, seen)
+		walkVariables(n.ElseList, rootDot, seen)
+	case *parse.WithNode:
+		walkVariables(n.Pipe, rootDot, seen)
+		walkVariables(n.List, false, seen)
+		walkVariables(n.ElseList, rootDot, seen)
+	case *parse.TemplateNode:
+		walkVariables(n.Pipe, rootDot, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkVariables(cmd, rootDot, seen)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) == 3 && rootDot {
+			if fn, ok := n.Args[0].(*parse.IdentifierNode); ok && fn.Ident == "index" {
+				_, dot := n.Args[1].(*parse.DotNode)
+				if key, ok := n.Args[2].(*parse.StringNode); ok && dot {
+					seen[key.Text] = struct{}{}
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			walkVariables(arg, rootDot, seen)
+		}
+	case *parse.ChainNode:
+		walkVariables(n.Node, rootDot, seen)
+	case *parse.FieldNode:
+		if rootDot {
+			seen[n.Ident[0]] = struct{}{}
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			seen[n.Ident[1]] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing creates the OpenTelemetry spans braid records while
+// reconciling Applications and configures their export over OTLP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name is the instrumentation scope of the spans braid records.
+const Name = "github.com/james226/braid"
+
+// Attributes recorded on braid spans.
+const (
+	ApplicationNamespace = attribute.Key("braid.application.namespace")
+	ApplicationName      = attribute.Key("braid.application.name")
+	ApplicationTemplate  = attribute.Key("braid.application_template.name")
+	TemplateRevision     = attribute.Key("braid.template.resource_version")
+	ObjectTemplate       = attribute.Key("braid.object_template.name")
+	ObjectAPIVersion     = attribute.Key("braid.object.api_version")
+	ObjectKind           = attribute.Key("braid.object.kind")
+	ObjectNamespace      = attribute.Key("braid.object.namespace")
+	ObjectName           = attribute.Key("braid.object.name")
+	ApplyStrategy        = attribute.Key("braid.object.apply_strategy")
+)
+
+// Start starts a span that is a child of the span in ctx, using the tracer
+// provider of that span. Without a span in ctx the new span is not recorded.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(Name)
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Options configures the export of spans.
+type Options struct {
+	// Endpoint of the OTLP gRPC receiver, such as otel-collector:4317.
+	// Spans are not exported when it is empty.
+	Endpoint string
+
+	// Insecure disables TLS for the connection to the receiver.
+	Insecure bool
+
+	// SampleRatio is the fraction of reconciles that are traced.
+	SampleRatio float64
+}
+
+// Setup installs a global tracer provider exporting spans as configured by
+// opts. The returned function flushes and stops the export.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "braid"))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}